@@ -4,19 +4,28 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli/adv2v2"
-	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli/clu2adv"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli/lint"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli/migratestate"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli/predictdrift"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli/verifyplan"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
 	"github.com/spf13/cobra"
 )
 
 func main() {
 	terraformCmd := &cobra.Command{
 		Use:     "terraform",
-		Short:   "Utilities for Terraform's MongoDB Atlas Provider",
+		Short:   cli.TerraformShort,
 		Aliases: []string{"tf"},
 	}
-	terraformCmd.AddCommand(clu2adv.Builder())
-	terraformCmd.AddCommand(adv2v2.Builder())
+	for i := range convert.Converters {
+		terraformCmd.AddCommand(cli.ConverterBuilder(&convert.Converters[i]))
+	}
+	terraformCmd.AddCommand(migratestate.Builder())
+	terraformCmd.AddCommand(verifyplan.Builder())
+	terraformCmd.AddCommand(predictdrift.Builder())
+	terraformCmd.AddCommand(lint.Builder())
 
 	completionOption := &cobra.CompletionOptions{
 		DisableDefaultCmd:   true,
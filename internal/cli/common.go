@@ -3,20 +3,32 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
 	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/file"
 	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/flags"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
+// TerraformShort is the description of the terraform command, also used in the plugin manifest.
+const TerraformShort = "Utilities for Terraform's MongoDB Atlas Provider"
+
 type ConvertFn func(config []byte) ([]byte, error)
 
 // BaseOpts contains common functionality for CLI commands that convert files.
 type BaseOpts struct {
-	Fs            afero.Fs
-	Convert       ConvertFn
+	Fs      afero.Fs
+	Convert ConvertFn
+	// AfterGenerate are called in order once after the output file is generated successfully the first time,
+	// before watching, e.g. to write other files only if the conversion succeeds.
+	AfterGenerate []func() error
 	File          string
 	Output        string
 	ReplaceOutput bool
@@ -47,6 +59,11 @@ func (o *BaseOpts) run() error {
 	if err := o.generateFile(false); err != nil {
 		return err
 	}
+	for _, afterGenerate := range o.AfterGenerate {
+		if err := afterGenerate(); err != nil {
+			return err
+		}
+	}
 	if o.Watch {
 		return o.watchFile()
 	}
@@ -117,6 +134,96 @@ func (o *BaseOpts) waitForFileEvent(watcher *fsnotify.Watcher) error {
 	return nil
 }
 
+// WithVerify returns a conversion function that also checks the converted clusters have the same topology
+// as the original ones. Clusters that can't be verified are written to warnings.
+func WithVerify(convertFn ConvertFn, warnings io.Writer) ConvertFn {
+	return func(config []byte) ([]byte, error) {
+		outConfig, err := convertFn(config)
+		if err != nil {
+			return nil, err
+		}
+		report, err := convert.VerifyTopology(config, outConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, notVerified := range report.NotVerified {
+			fmt.Fprintf(warnings, "WARNING: topology not verified, %s\n", notVerified)
+		}
+		if len(report.Differences) > 0 {
+			return nil, fmt.Errorf("topology verification failed:\n  - %s", strings.Join(report.Differences, "\n  - "))
+		}
+		return outConfig, nil
+	}
+}
+
+// WithValidate returns a conversion function that also checks the resources and data sources changed by the
+// conversion against the embedded provider schema, so the output file is not written if there are errors.
+// filename is the output file used in the error positions. Resources and data sources whose type is not in the
+// schema snapshot are written to warnings.
+func WithValidate(convertFn ConvertFn, filename string, warnings io.Writer) ConvertFn {
+	return func(config []byte) ([]byte, error) {
+		outConfig, err := convertFn(config)
+		if err != nil {
+			return nil, err
+		}
+		report, err := convert.ValidateSchema(config, outConfig, filename)
+		if err != nil {
+			return nil, err
+		}
+		for _, notValidated := range report.NotValidated {
+			fmt.Fprintf(warnings, "WARNING: schema not validated, %s\n", notValidated)
+		}
+		if len(report.Errors) > 0 {
+			lines := make([]string, 0, len(report.Errors))
+			for _, schemaError := range report.Errors {
+				lines = append(lines, schemaError.Error())
+			}
+			return nil, fmt.Errorf("schema validation failed:\n  - %s", strings.Join(lines, "\n  - "))
+		}
+		return outConfig, nil
+	}
+}
+
+// GetProviderFileUpdates returns the content of the other Terraform files in the directory of the input file,
+// e.g. versions.tf, with the mongodbatlas version constraint updated. Files that don't change are not returned.
+// The input and output files are updated by the conversion itself.
+// Nothing is written so the files can be updated only if the conversion succeeds.
+func (o *BaseOpts) GetProviderFileUpdates(includeMoved bool) (map[string][]byte, error) {
+	files, err := afero.Glob(o.Fs, filepath.Join(filepath.Dir(o.File), "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	updates := make(map[string][]byte)
+	for _, filename := range files {
+		if filepath.Clean(filename) == filepath.Clean(o.File) || filepath.Clean(filename) == filepath.Clean(o.Output) {
+			continue
+		}
+		config, err := afero.ReadFile(o.Fs, filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+		}
+		updated, err := convert.UpdateProviderVersion(config, includeMoved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update provider version in file %s: %w", filename, err)
+		}
+		if updated != nil {
+			updates[filename] = updated
+		}
+	}
+	return updates, nil
+}
+
+// WriteProviderFiles writes the files returned by GetProviderFileUpdates, the updated files are written to out.
+func (o *BaseOpts) WriteProviderFiles(updates map[string][]byte, out io.Writer) error {
+	for _, filename := range slices.Sorted(maps.Keys(updates)) {
+		if err := afero.WriteFile(o.Fs, filename, updates[filename], 0o600); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", filename, err)
+		}
+		fmt.Fprintf(out, "Updated provider version constraint in file %s\n", filename)
+	}
+	return nil
+}
+
 // SetupCommonFlags sets up the common flags used by all commands.
 func SetupCommonFlags(cmd *cobra.Command, opts *BaseOpts) {
 	cmd.Flags().StringVarP(&opts.File, flags.File, flags.FileShort, "", "input file")
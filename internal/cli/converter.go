@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/file"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/flags"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// ConverterBuilder returns the command of a converter, with the common flags, the option flags of the converter,
+// and --stateScript and --verify if the converter supports them. The rules in the --rules file are applied after
+// the conversion, and the result is checked against the provider schema with --validate.
+func ConverterBuilder(c *convert.Converter) *cobra.Command {
+	o := &struct {
+		BaseOpts
+		enabled     []bool
+		rules       []convert.Rule
+		rulesFile   string
+		stateScript string
+		modulePath  string
+		verify      bool
+		validate    bool
+	}{
+		BaseOpts: BaseOpts{
+			Fs: afero.NewOsFs(),
+		},
+		enabled: make([]bool, len(c.Flags)),
+	}
+	getOptions := func() convert.Options {
+		var opts convert.Options
+		for i, flag := range c.Flags {
+			if o.enabled[i] {
+				flag.Enable(&opts)
+			}
+		}
+		return opts
+	}
+	var migrations []convert.StateMigration // state migrations of the last conversion
+	o.Convert = func(config []byte) ([]byte, error) {
+		opts := getOptions()
+		outConfig, err := c.Convert(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		if o.stateScript != "" {
+			if migrations, err = c.StateMigrations(config, o.modulePath, opts); err != nil {
+				return nil, err
+			}
+		}
+		return convert.ApplyRules(outConfig, o.rules)
+	}
+	cmd := &cobra.Command{
+		Use:     c.Name,
+		Short:   c.Short,
+		Long:    c.Long,
+		Aliases: c.Aliases,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.stateScript == "" || o.ReplaceOutput {
+				return nil
+			}
+			if err := file.MustNotExist(o.Fs, o.stateScript); err != nil {
+				return err
+			}
+			return file.MustNotExist(o.Fs, getManifestFile(o.stateScript))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.rulesFile != "" {
+				rules, err := loadRules(o.Fs, o.rulesFile)
+				if err != nil {
+					return err
+				}
+				o.rules = rules
+			}
+			if o.stateScript != "" {
+				o.AfterGenerate = append(o.AfterGenerate, func() error {
+					return writeStateScript(o.Fs, o.stateScript, migrations)
+				})
+			}
+			if o.verify {
+				o.Convert = WithVerify(o.Convert, cmd.ErrOrStderr())
+			}
+			if o.validate {
+				o.Convert = WithValidate(o.Convert, o.Output, cmd.ErrOrStderr())
+			}
+			if opts := getOptions(); opts.UpdateProvider {
+				updates, err := o.GetProviderFileUpdates(opts.IncludeMoved)
+				if err != nil {
+					return err
+				}
+				o.AfterGenerate = append(o.AfterGenerate, func() error {
+					return o.WriteProviderFiles(updates, cmd.ErrOrStderr())
+				})
+			}
+			return o.RunE(cmd, args)
+		},
+	}
+	SetupCommonFlags(cmd, &o.BaseOpts)
+	for i, flag := range c.Flags {
+		cmd.Flags().BoolVarP(&o.enabled[i], flag.Name, flag.Shorthand, false, flag.Usage)
+	}
+	for _, exclusive := range c.ExclusiveFlags {
+		cmd.MarkFlagsMutuallyExclusive(exclusive...)
+	}
+	cmd.Flags().StringVar(&o.rulesFile, flags.Rules, "",
+		"file with rules in HCL format to apply to the converted file")
+	cmd.Flags().BoolVar(&o.validate, flags.Validate, false,
+		"validate the converted resources against the provider schema before writing the output file")
+	if c.StateMigrations != nil {
+		cmd.Flags().StringVarP(&o.stateScript, flags.StateScript, flags.StateScriptShort, "",
+			"shell script file with the terraform state commands to migrate the clusters, a JSON manifest is also created")
+		cmd.Flags().StringVar(&o.modulePath, flags.ModulePath, "",
+			"module address of the input file used in the state script, e.g. module.db")
+	}
+	if c.Verify {
+		cmd.Flags().BoolVar(&o.verify, flags.Verify, false,
+			"verify the converted clusters have the same topology as the original ones")
+	}
+	return cmd
+}
+
+// ConverterDocOptions returns the Markdown list of the options of a converter for its documentation,
+// the first source and target types are the main ones used in the description of the input and output files.
+func ConverterDocOptions(c *convert.Converter) string {
+	lines := []string{
+		fmt.Sprintf("`--%s` or `-%s`: Input file path containing the `%s` configuration",
+			flags.File, flags.FileShort, c.SourceTypes[0]),
+		fmt.Sprintf("`--%s` or `-%s`: Output file path for the converted `%s` configuration",
+			flags.Output, flags.OutputShort, c.TargetTypes[0]),
+		fmt.Sprintf("`--%s` or `-%s`: Overwrite the file at the output path if it already exists. "+
+			"You can also modify the input file in-place.", flags.ReplaceOutput, flags.ReplaceOutputShort),
+		fmt.Sprintf("`--%s` or `-%s`: Keep the plugin running and watching for changes in the input file",
+			flags.Watch, flags.WatchShort),
+	}
+	for _, flag := range c.Flags {
+		name := "`--" + flag.Name + "`"
+		if flag.Shorthand != "" {
+			name += " or `-" + flag.Shorthand + "`"
+		}
+		lines = append(lines, name+": "+flag.Doc)
+	}
+	lines = append(lines, fmt.Sprintf("`--%s`: File with rules to apply to the converted file, "+
+		"see [Rules](./rules.md)", flags.Rules),
+		fmt.Sprintf("`--%s`: Check the converted resources and data sources against the Provider 2.0.0 schema "+
+			"before writing the output file, see [Schema validation](./validate.md)", flags.Validate))
+	if c.StateMigrations != nil {
+		lines = append(lines,
+			fmt.Sprintf("`--%s` or `-%s`: Write a shell script with the `terraform import` and `terraform state rm` "+
+				"commands to migrate the clusters, and a JSON manifest with the same information, "+
+				"see [State migration script](#state-migration-script)", flags.StateScript, flags.StateScriptShort),
+			fmt.Sprintf("`--%s`: Module address of the input file used in the state migration script, "+
+				"for example `module.db`", flags.ModulePath))
+	}
+	if c.Verify {
+		lines = append(lines, fmt.Sprintf("`--%s`: Check that the converted clusters have the same topology as the "+
+			"original ones, see [Verify topology](#verify-topology)", flags.Verify))
+	}
+	return "- " + strings.Join(lines, "\n- ") + "\n"
+}
+
+// ManifestDescription returns the description of the terraform command in manifest.template.yml, it lists the
+// conversion commands so they're shown by the Atlas CLI, e.g. in atlas plugin list.
+func ManifestDescription() string {
+	commands := make([]string, len(convert.Converters))
+	for i := range convert.Converters {
+		c := &convert.Converters[i]
+		commands[i] = fmt.Sprintf("%s (%s)", c.Name, strings.Join(c.Aliases, ", "))
+	}
+	return TerraformShort + ", conversion commands " + strings.Join(commands, ", ")
+}
+
+func loadRules(fs afero.Fs, filename string) ([]convert.Rule, error) {
+	content, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+	return convert.LoadRules(content, filename)
+}
+
+// writeStateScript writes the state migration script and its JSON manifest,
+// it's called after the output file is written so they're not created if the conversion fails.
+func writeStateScript(fs afero.Fs, scriptFile string, migrations []convert.StateMigration) error {
+	if migrations == nil {
+		migrations = []convert.StateMigration{}
+	}
+	manifest, err := json.MarshalIndent(migrations, "", "  ")
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // the script must be executable
+	if err := afero.WriteFile(fs, scriptFile, convert.StateScript(migrations), 0o700); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", scriptFile, err)
+	}
+	manifestFile := getManifestFile(scriptFile)
+	if err := afero.WriteFile(fs, manifestFile, append(manifest, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", manifestFile, err)
+	}
+	return nil
+}
+
+// getManifestFile returns the JSON manifest file of a state script, e.g. migrate.json for migrate.sh.
+func getManifestFile(scriptFile string) string {
+	return strings.TrimSuffix(scriptFile, filepath.Ext(scriptFile)) + ".json"
+}
@@ -0,0 +1,179 @@
+package cli_test
+
+import (
+	"flag"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the command options in the documentation files and the manifest")
+
+const (
+	optionsStart  = "### Command Options\n\n"
+	optionsEnd    = "\n## "
+	manifestStart = "    terraform:\n        description: "
+)
+
+// TestConverterDocs checks that the command options in the documentation of each converter are the ones generated
+// from its definition, run with -update to regenerate them.
+func TestConverterDocs(t *testing.T) {
+	fs := afero.NewOsFs()
+	for i := range convert.Converters {
+		c := &convert.Converters[i]
+		t.Run(c.Name, func(t *testing.T) {
+			filename := filepath.Join("..", "..", "docs", "command_"+c.Aliases[0]+".md")
+			content, err := afero.ReadFile(fs, filename)
+			require.NoError(t, err)
+			before, rest, found := strings.Cut(string(content), optionsStart)
+			require.True(t, found, "options section not found in %s", filename)
+			_, after, found := strings.Cut(rest, optionsEnd)
+			require.True(t, found, "end of options section not found in %s", filename)
+			expected := before + optionsStart + cli.ConverterDocOptions(c) + optionsEnd + after
+			if *update {
+				require.NoError(t, afero.WriteFile(fs, filename, []byte(expected), 0o600))
+				return
+			}
+			assert.Equal(t, expected, string(content), "run the tests with -update to regenerate the documentation")
+		})
+	}
+}
+
+// TestManifest checks that the description of the terraform command in the plugin manifest is the one generated
+// from the converters, run with -update to regenerate it.
+func TestManifest(t *testing.T) {
+	fs := afero.NewOsFs()
+	filename := filepath.Join("..", "..", "manifest.template.yml")
+	content, err := afero.ReadFile(fs, filename)
+	require.NoError(t, err)
+	before, rest, found := strings.Cut(string(content), manifestStart)
+	require.True(t, found, "terraform command not found in %s", filename)
+	_, after, found := strings.Cut(rest, "\n")
+	require.True(t, found, "end of terraform command description not found in %s", filename)
+	expected := before + manifestStart + strconv.Quote(cli.ManifestDescription()) + "\n" + after
+	if *update {
+		require.NoError(t, afero.WriteFile(fs, filename, []byte(expected), 0o600))
+		return
+	}
+	assert.Equal(t, expected, string(content), "run the tests with -update to regenerate the manifest")
+}
+
+func TestConverterBuilder(t *testing.T) {
+	for i := range convert.Converters {
+		c := &convert.Converters[i]
+		cmd := cli.ConverterBuilder(c)
+		assert.Equal(t, c.Name, cmd.Name())
+		assert.Equal(t, c.Aliases, cmd.Aliases)
+		for _, flag := range c.Flags {
+			assert.NotNil(t, cmd.Flags().Lookup(flag.Name), "flag %s not found in %s", flag.Name, c.Name)
+		}
+	}
+}
+
+const (
+	validCluster = `resource "mongodbatlas_cluster" "free" {
+  project_id                  = var.project_id
+  name                        = "free"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M0"
+}
+`
+	invalidCluster = `resource "mongodbatlas_cluster" "free" {
+  project_id    = var.project_id
+  name          = "free"
+  provider_name = "TENANT"
+}
+`
+)
+
+// runClusterToAdvancedCluster runs clusterToAdvancedCluster in dir with input as main.tf and out.tf as output,
+// created empty before if createOutput is true.
+func runClusterToAdvancedCluster(t *testing.T, dir, input string, createOutput bool, args ...string) error {
+	t.Helper()
+	i := slices.IndexFunc(convert.Converters, func(c convert.Converter) bool {
+		return c.Name == "clusterToAdvancedCluster"
+	})
+	require.GreaterOrEqual(t, i, 0)
+	fs := afero.NewOsFs()
+	output := filepath.Join(dir, "out.tf")
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "main.tf"), []byte(input), 0o600))
+	if createOutput {
+		require.NoError(t, afero.WriteFile(fs, output, nil, 0o600))
+	}
+	cmd := cli.ConverterBuilder(&convert.Converters[i])
+	cmd.SetArgs(append([]string{"-f", filepath.Join(dir, "main.tf"), "-o", output}, args...))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	return cmd.Execute()
+}
+
+func TestConverterUpdateProviderFiles(t *testing.T) {
+	const versions = `terraform {
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 1.26"
+    }
+  }
+}
+`
+	testCases := map[string]struct {
+		input        string
+		createOutput bool
+		updated      bool
+	}{
+		"conversion error": {input: invalidCluster},
+		"output exists":    {input: validCluster, createOutput: true},
+		"updated":          {input: validCluster, updated: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewOsFs()
+			dir := t.TempDir()
+			versionsFile := filepath.Join(dir, "versions.tf")
+			require.NoError(t, afero.WriteFile(fs, versionsFile, []byte(versions), 0o600))
+			err := runClusterToAdvancedCluster(t, dir, tc.input, tc.createOutput, "--updateProvider")
+			assert.Equal(t, tc.updated, err == nil)
+			content, err := afero.ReadFile(fs, versionsFile)
+			require.NoError(t, err)
+			assert.Equal(t, tc.updated, versions != string(content))
+		})
+	}
+}
+
+func TestConverterStateScript(t *testing.T) {
+	testCases := map[string]struct {
+		input        string
+		createOutput bool
+		written      bool
+	}{
+		"conversion error": {input: invalidCluster},
+		"output exists":    {input: validCluster, createOutput: true},
+		"written":          {input: validCluster, written: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewOsFs()
+			dir := t.TempDir()
+			err := runClusterToAdvancedCluster(t, dir, tc.input, tc.createOutput,
+				"--stateScript", filepath.Join(dir, "migrate.sh"))
+			assert.Equal(t, tc.written, err == nil)
+			for _, name := range []string{"migrate.sh", "migrate.json"} {
+				exists, err := afero.Exists(fs, filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, tc.written, exists, name)
+			}
+		})
+	}
+}
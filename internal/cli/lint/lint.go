@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/file"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/flags"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type opts struct {
+	fs   afero.Fs
+	file string
+}
+
+func Builder() *cobra.Command {
+	o := &opts{fs: afero.NewOsFs()}
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check the topology of advanced_cluster resources",
+		Long: "Check the topology of mongodbatlas_advanced_cluster resources, converted or hand-written, against the " +
+			"Atlas rules for electable nodes, priorities, regions and auto scaling",
+		SilenceUsage: true,
+		RunE:         o.runE,
+	}
+	cmd.Flags().StringVarP(&o.file, flags.File, flags.FileShort, "", "input file")
+	_ = cmd.MarkFlagRequired(flags.File)
+	return cmd
+}
+
+func (o *opts) runE(cmd *cobra.Command, args []string) error {
+	if err := file.MustExist(o.fs, o.file); err != nil {
+		return err
+	}
+	content, err := afero.ReadFile(o.fs, o.file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", o.file, err)
+	}
+	report, err := convert.LintTopology(content, o.file)
+	if err != nil {
+		return err
+	}
+	for _, notLinted := range report.NotLinted {
+		fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: topology not linted, %s\n", notLinted)
+	}
+	out := cmd.OutOrStdout()
+	for i := range report.Issues {
+		fmt.Fprintln(out, report.Issues[i].String())
+	}
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("found %d topology issues in clusters", len(report.Issues))
+	}
+	fmt.Fprintln(out, "No topology issues found in clusters")
+	return nil
+}
@@ -0,0 +1,25 @@
+package migratestate
+
+import (
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/cli"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func Builder() *cobra.Command {
+	o := &cli.BaseOpts{
+		Fs:      afero.NewOsFs(),
+		Convert: convert.MigrateState,
+	}
+	cmd := &cobra.Command{
+		Use:   "migrateState",
+		Short: "Migrate cluster resources in a Terraform state file",
+		Long: "Migrate mongodbatlas_cluster and provider version 1 mongodbatlas_advanced_cluster resources" +
+			" in a local Terraform state file to mongodbatlas_advanced_cluster in provider version 2," +
+			" the output file can be uploaded with terraform state push",
+		RunE: o.RunE,
+	}
+	cli.SetupCommonFlags(cmd, o)
+	return cmd
+}
@@ -0,0 +1,73 @@
+package predictdrift
+
+import (
+	"fmt"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/file"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/flags"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type opts struct {
+	fs         afero.Fs
+	file       string
+	state      string
+	modulePath string
+}
+
+func Builder() *cobra.Command {
+	o := &opts{fs: afero.NewOsFs()}
+	cmd := &cobra.Command{
+		Use:   "predictDrift",
+		Short: "Predict plan differences between converted clusters and state",
+		Long: "Compare the literal values of mongodbatlas_advanced_cluster resources in a converted Terraform configuration" +
+			" with the attributes of the same clusters in a local Terraform state file, and list the fields that" +
+			" would show a difference in the first plan",
+		SilenceUsage: true,
+		RunE:         o.runE,
+	}
+	cmd.Flags().StringVarP(&o.file, flags.File, flags.FileShort, "", "input file with the converted configuration")
+	_ = cmd.MarkFlagRequired(flags.File)
+	cmd.Flags().StringVar(&o.state, flags.State, "", "input Terraform state file")
+	_ = cmd.MarkFlagRequired(flags.State)
+	cmd.Flags().StringVar(&o.modulePath, flags.ModulePath, "",
+		"module address of the input file in the state, e.g. module.db")
+	return cmd
+}
+
+func (o *opts) runE(cmd *cobra.Command, args []string) error {
+	config, err := o.readFile(o.file)
+	if err != nil {
+		return err
+	}
+	state, err := o.readFile(o.state)
+	if err != nil {
+		return err
+	}
+	diffs, err := convert.PredictDrift(config, state, o.modulePath)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for _, diff := range diffs {
+		fmt.Fprintln(out, diff)
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("found %d fields with differences between configuration and state", len(diffs))
+	}
+	fmt.Fprintln(out, "No differences found between configuration and state")
+	return nil
+}
+
+func (o *opts) readFile(name string) ([]byte, error) {
+	if err := file.MustExist(o.fs, name); err != nil {
+		return nil, err
+	}
+	content, err := afero.ReadFile(o.fs, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", name, err)
+	}
+	return content, nil
+}
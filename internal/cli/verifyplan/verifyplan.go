@@ -0,0 +1,54 @@
+package verifyplan
+
+import (
+	"fmt"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/file"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/flags"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type opts struct {
+	fs   afero.Fs
+	file string
+}
+
+func Builder() *cobra.Command {
+	o := &opts{fs: afero.NewOsFs()}
+	cmd := &cobra.Command{
+		Use:   "verifyPlan",
+		Short: "Verify a Terraform plan doesn't modify the clusters",
+		Long: "Verify a Terraform plan in JSON format, generated with terraform show -json, doesn't delete, replace" +
+			" or change the topology of mongodbatlas_cluster and mongodbatlas_advanced_cluster resources",
+		SilenceUsage: true,
+		RunE:         o.runE,
+	}
+	cmd.Flags().StringVarP(&o.file, flags.File, flags.FileShort, "", "input plan file in JSON format")
+	_ = cmd.MarkFlagRequired(flags.File)
+	return cmd
+}
+
+func (o *opts) runE(cmd *cobra.Command, args []string) error {
+	if err := file.MustExist(o.fs, o.file); err != nil {
+		return err
+	}
+	content, err := afero.ReadFile(o.fs, o.file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", o.file, err)
+	}
+	issues, err := convert.VerifyPlan(content)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for i := range issues {
+		fmt.Fprintln(out, issues[i].String())
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("plan has %d unsafe changes in clusters", len(issues))
+	}
+	fmt.Fprintln(out, "No unsafe changes in clusters found in the plan")
+	return nil
+}
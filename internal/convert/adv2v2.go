@@ -16,25 +16,51 @@ var (
 // AdvancedClusterToV2 transforms all mongodbatlas_advanced_cluster resource definitions in a
 // Terraform configuration file from SDKv2 schema to TPF (Terraform Plugin Framework) schema.
 // All other resources and data sources are left untouched.
-func AdvancedClusterToV2(config []byte) ([]byte, error) {
+func AdvancedClusterToV2(config []byte, opts Options) ([]byte, error) {
 	parser, err := hcl.GetParser(config)
 	if err != nil {
 		return nil, err
 	}
+	newTokens := make(map[*hclwrite.Block]hclwrite.Tokens)
+	mappers := make(map[*hclwrite.Block]*pathMapper)
+	var checkTokens []hclwrite.Tokens
 	parserb := parser.Body()
 	for _, block := range parserb.Blocks() {
-		updated, err := processResource(block)
+		original := block.BuildTokens(nil).Bytes()
+		updated, err := processResource(block, opts)
 		if err != nil {
 			return nil, err
 		}
 		if updated {
+			mappers[block] = newPathMapper(block, original)
+			checkRegions(block.Body(), opts.NormalizeRegions)
+		}
+		if updated && opts.ExtractLocals {
+			if locals := extractLocals(block); locals != nil {
+				newTokens[block] = appendTokensAfter(newTokens[block], locals.BuildTokens(nil))
+			}
+		}
+		if updated && opts.IncludeCheck {
+			if label := getResourceLabel(block); label != "" {
+				checkTokens = append(checkTokens, getCheckTokens(label, original))
+			}
+		}
+		if updated || processDataSource(block) {
 			addComments(block, true)
 		}
 	}
-	return parser.Bytes(), nil
+	updateLifecycles(parserb, advCluster, mappers)
+	updateRepSpecIDReferences(parserb, advCluster, nil)
+	updateObjectReferences(parserb, advClusterObjectTypes, commentObjectUpdated)
+	checkForReferences(parserb, advClusterObjectTypes, commentForReferences)
+	fillCheckBlocks(parserb, checkTokens)
+	if opts.UpdateProvider {
+		updateProviderVersion(parserb, false)
+	}
+	return hcl.BytesWithTokensAfter(parser, newTokens), nil
 }
 
-func processResource(resource *hclwrite.Block) (bool, error) {
+func processResource(resource *hclwrite.Block, opts Options) (bool, error) {
 	if resource.Type() != resourceType || getResourceName(resource) != advCluster {
 		return false, nil
 	}
@@ -46,7 +72,7 @@ func processResource(resource *hclwrite.Block) (bool, error) {
 		return false, nil
 	}
 	diskSizeGB, _ := hcl.PopAttr(resourceb, nDiskSizeGB, errRoot) // ok to fail as it's optional
-	if err := processRepSpecs(resourceb, diskSizeGB); err != nil {
+	if err := processRepSpecs(resourceb, diskSizeGB, opts); err != nil {
 		return false, err
 	}
 	if err := processCommonOptionalBlocks(resourceb); err != nil {
@@ -55,7 +81,17 @@ func processResource(resource *hclwrite.Block) (bool, error) {
 	return true, nil
 }
 
-func processRepSpecs(resourceb *hclwrite.Body, diskSizeGB hclwrite.Tokens) error {
+// processDataSource removes the arguments of mongodbatlas_advanced_cluster and mongodbatlas_advanced_clusters
+// data sources not supported in provider 2.0.0, it returns true if any was removed.
+func processDataSource(block *hclwrite.Block) bool {
+	if block.Type() != dataSourceType ||
+		(getResourceName(block) != advCluster && getResourceName(block) != advClusterPlural) {
+		return false
+	}
+	return block.Body().RemoveAttribute(nUseRepSpecPerShard) != nil
+}
+
+func processRepSpecs(resourceb *hclwrite.Body, diskSizeGB hclwrite.Tokens, opts Options) error {
 	d, err := processRepSpecsWithDynamicBlock(resourceb, diskSizeGB)
 	if err != nil {
 		return err
@@ -69,7 +105,7 @@ func processRepSpecs(resourceb *hclwrite.Body, diskSizeGB hclwrite.Tokens) error
 	if len(repSpecBlocks) == 0 {
 		return fmt.Errorf("must have at least one replication_specs")
 	}
-	hasVariableShards := hasVariableNumShards(repSpecBlocks)
+	useTokens := hasVariableNumShards(repSpecBlocks) || (opts.CompactShards && hasMultipleNumShards(repSpecBlocks))
 	var resultTokens []hclwrite.Tokens
 	var resultBodies []*hclwrite.Body
 	for _, block := range repSpecBlocks {
@@ -95,8 +131,8 @@ func processRepSpecs(resourceb *hclwrite.Body, diskSizeGB hclwrite.Tokens) error
 			}
 			blockb.SetAttributeRaw(nConfig, hcl.TokensArray(configs))
 		}
-		if hasVariableShards {
-			resultTokens = append(resultTokens, processNumShardsWhenSomeIsVariable(shardsAttr, blockb))
+		if useTokens {
+			resultTokens = append(resultTokens, processNumShardsTokens(shardsAttr, blockb, opts.CompactShards))
 			continue
 		}
 		numShardsVal := 1 // Default to 1 if num_shards is not set
@@ -107,7 +143,7 @@ func processRepSpecs(resourceb *hclwrite.Body, diskSizeGB hclwrite.Tokens) error
 			resultBodies = append(resultBodies, blockb)
 		}
 	}
-	if hasVariableShards {
+	if useTokens {
 		resourceb.SetAttributeRaw(nRepSpecs, hcl.TokensFuncConcat(resultTokens...))
 	} else {
 		resourceb.SetAttributeRaw(nRepSpecs, hcl.TokensArray(resultBodies))
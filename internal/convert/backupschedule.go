@@ -0,0 +1,151 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+)
+
+var (
+	// backupScheduleAttrs are the snapshot_backup_policy attributes with the same name in cloud_backup_schedule.
+	backupScheduleAttrs = []string{
+		"reference_hour_of_day", "reference_minute_of_hour", "restore_window_days", "update_snapshots",
+	}
+	// backupFrequencyTypes are the policy_item frequency types, each one is a policy_item_<frequency> block.
+	backupFrequencyTypes = []string{"hourly", "daily", "weekly", "monthly", "yearly"}
+	// backupSingleFrequencyTypes are the frequency types allowed only once in cloud_backup_schedule.
+	backupSingleFrequencyTypes = []string{"hourly", "daily"}
+)
+
+// extractBackupSchedule removes the snapshot_backup_policy block of a converted advanced cluster and returns
+// the equivalent mongodbatlas_cloud_backup_schedule resource referencing the cluster.
+// Warnings are added as comments in the resource when the mapping is not exact.
+// nil is returned if the cluster doesn't have a snapshot_backup_policy with values.
+func extractBackupSchedule(resource *hclwrite.Block) *hclwrite.Block {
+	resourceb := resource.Body()
+	policies := collectBlocks(resourceb, nSnapshotBackupPolicy)
+	label := getResourceLabel(resource)
+	if len(policies) == 0 || label == "" {
+		return nil
+	}
+	schedule := hclwrite.NewBlock(resourceType, []string{backupSchedule, label})
+	scheduleb := schedule.Body()
+	address := fmt.Sprintf("%s.%s", advCluster, label)
+	if count := resourceb.GetAttribute(nCount); count != nil {
+		scheduleb.SetAttributeRaw(nCount, count.Expr().BuildTokens(nil))
+		address += fmt.Sprintf("[%s.index]", nCount)
+	} else if forEach := resourceb.GetAttribute(nForEach); forEach != nil {
+		scheduleb.SetAttributeRaw(nForEach, forEach.Expr().BuildTokens(nil))
+		address += fmt.Sprintf("[%s.%s]", nEach, nKey)
+	}
+	scheduleb.SetAttributeRaw(nProjectID, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", address, nProjectID)))
+	scheduleb.SetAttributeRaw(nClusterName, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", address, nName)))
+	var removed, warnings []string
+	hasValues := false
+	for _, policy := range policies {
+		policyb := policy.Body()
+		for name := range policyb.Attributes() {
+			if !slices.Contains(backupScheduleAttrs, name) {
+				removed = append(removed, name)
+			}
+		}
+		for _, name := range backupScheduleAttrs {
+			if attr := policyb.GetAttribute(name); attr != nil {
+				scheduleb.SetAttributeRaw(name, attr.Expr().BuildTokens(nil))
+				hasValues = true
+			}
+		}
+		itemRemoved, itemWarnings, hasItems := fillBackupPolicyItems(scheduleb, policyb)
+		removed = append(removed, itemRemoved...)
+		warnings = append(warnings, itemWarnings...)
+		hasValues = hasValues || hasItems
+	}
+	if !hasValues {
+		return nil
+	}
+	if isBackupDisabled(resourceb) {
+		warnings = append(warnings, fmt.Sprintf(commentBackupDisabled, fmt.Sprintf("%s.%s", advCluster, label)))
+	}
+	scheduleb.AppendNewline()
+	if len(removed) > 0 {
+		slices.Sort(removed)
+		hcl.AppendComment(scheduleb, fmt.Sprintf(commentBackupRemoved, strings.Join(slices.Compact(removed), ", ")))
+	}
+	for _, warning := range warnings {
+		hcl.AppendComment(scheduleb, warning)
+	}
+	hcl.AppendComment(scheduleb, commentGeneratedBy)
+	return schedule
+}
+
+// fillBackupPolicyItems adds a policy_item_<frequency> block for each policy_item in the policies blocks.
+// It returns the names of the attributes not supported, the warnings, and if any policy item was added.
+func fillBackupPolicyItems(scheduleb, policyb *hclwrite.Body) (removed, warnings []string, hasItems bool) {
+	added := make(map[string]bool)
+	for _, policies := range policyb.Blocks() {
+		if policies.Type() != nPolicies {
+			removed, warnings = appendUnsupportedBlock(policies, "", removed, warnings)
+			continue
+		}
+		for name := range policies.Body().Attributes() {
+			removed = append(removed, nPolicies+"."+name)
+		}
+		for _, item := range policies.Body().Blocks() {
+			if item.Type() != nPolicyItem {
+				removed, warnings = appendUnsupportedBlock(item, nPolicies+".", removed, warnings)
+				continue
+			}
+			itemb := item.Body()
+			frequencyAttr := itemb.GetAttribute(nFrequencyType)
+			frequency, err := hcl.GetAttrString(frequencyAttr)
+			if err != nil || !slices.Contains(backupFrequencyTypes, frequency) {
+				warnings = append(warnings, fmt.Sprintf(commentBackupFrequency, getFrequencyDesc(frequencyAttr)))
+				continue
+			}
+			if added[frequency] && slices.Contains(backupSingleFrequencyTypes, frequency) {
+				warnings = append(warnings, fmt.Sprintf(commentBackupDuplicate, frequency))
+				continue
+			}
+			added[frequency] = true
+			itemb.RemoveAttribute(nFrequencyType)
+			if itemb.GetAttribute(nID) != nil {
+				itemb.RemoveAttribute(nID)
+				removed = append(removed, nPolicyItem+"."+nID)
+			}
+			scheduleb.AppendNewBlock(nPolicyItem+"_"+frequency, nil).Body().AppendUnstructuredTokens(
+				hcl.RemoveLeadingNewline(itemb.BuildTokens(nil)))
+			hasItems = true
+		}
+	}
+	slices.Sort(warnings)
+	return removed, slices.Compact(warnings), hasItems
+}
+
+// appendUnsupportedBlock adds a warning for dynamic blocks as they can't be converted,
+// other blocks are not supported in cloud_backup_schedule and are added to removed.
+func appendUnsupportedBlock(block *hclwrite.Block, prefix string, removed, warnings []string) (newRemoved,
+	newWarnings []string) {
+	if block.Type() == nDynamic {
+		return removed, append(warnings, fmt.Sprintf(commentBackupDynamic, getResourceName(block)))
+	}
+	return append(removed, prefix+block.Type()), warnings
+}
+
+// isBackupDisabled returns true if backup_enabled is not set or is false, expressions are not evaluated.
+func isBackupDisabled(resourceb *hclwrite.Body) bool {
+	attr := resourceb.GetAttribute(nBackupEnabled)
+	if attr == nil {
+		return true
+	}
+	return strings.TrimSpace(hcl.GetAttrExpr(attr)) == "false"
+}
+
+func getFrequencyDesc(attr *hclwrite.Attribute) string {
+	if attr == nil {
+		return "not set"
+	}
+	return strings.TrimSpace(hcl.GetAttrExpr(attr))
+}
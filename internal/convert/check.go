@@ -0,0 +1,85 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// getCheckTokens returns a check block asserting the converted cluster keeps the topology of its original definition:
+// number of shards, region names and total electable nodes.
+// A comment is returned instead if the original topology can't be evaluated statically.
+func getCheckTokens(label string, original []byte) hclwrite.Tokens {
+	blocks, err := getResourceBodies(original)
+	if err != nil || len(blocks) != 1 {
+		return hcl.TokensComment(fmt.Sprintf(commentCheckNotGenerated, label, "resource can't be parsed"))
+	}
+	if _, found := blocks[0].Body.Attributes[nCount]; found {
+		return hcl.TokensComment(fmt.Sprintf(commentCheckNotGenerated, label, "resources with count are not supported"))
+	}
+	if _, found := blocks[0].Body.Attributes[nForEach]; found {
+		return hcl.TokensComment(fmt.Sprintf(commentCheckNotGenerated, label, "resources with for_each are not supported"))
+	}
+	topology, err := getConfigTopology(blocks[0])
+	if err != nil {
+		return hcl.TokensComment(fmt.Sprintf(commentCheckNotGenerated, label, err))
+	}
+	address := fmt.Sprintf("%s.%s", advCluster, label)
+	var regions []string
+	electableNodes := 0
+	isTenant := false
+	for _, shard := range topology.Shards {
+		for _, region := range shard.Regions {
+			if !slices.Contains(regions, region.RegionName) {
+				regions = append(regions, region.RegionName)
+			}
+			electableNodes += region.Nodes[nElectableSpecs].NodeCount
+			isTenant = isTenant || region.ProviderName == nTenant
+		}
+	}
+	slices.Sort(regions)
+	quotedRegions := make([]string, len(regions))
+	for i, region := range regions {
+		quotedRegions[i] = strconv.Quote(region)
+	}
+
+	block := hclwrite.NewBlock(nCheck, []string{label + "_topology"})
+	blockb := block.Body()
+	appendAssert(blockb, fmt.Sprintf("length(%s.%s) == %d", address, nRepSpecs, len(topology.Shards)),
+		fmt.Sprintf("%s number of shards must be %d", address, len(topology.Shards)))
+	appendAssert(blockb, fmt.Sprintf("toset(flatten([for spec in %s.%s : spec.%s[*].%s])) == toset([%s])",
+		address, nRepSpecs, nConfig, nRegionName, strings.Join(quotedRegions, ", ")),
+		fmt.Sprintf("%s must be deployed in regions %s", address, strings.Join(regions, ", ")))
+	if !isTenant { // node count is not configurable in tenant clusters
+		nodesExpr := fmt.Sprintf("[for config in spec.%s : try(config.%s.%s, 0)]", nConfig, nElectableSpecs, nNodeCount)
+		appendAssert(blockb, fmt.Sprintf("sum(flatten([for spec in %s.%s : %s])) == %d",
+			address, nRepSpecs, nodesExpr, electableNodes),
+			fmt.Sprintf("%s number of electable nodes must be %d", address, electableNodes))
+	}
+	return block.BuildTokens(nil)
+}
+
+// fillCheckBlocks adds the check blocks of the converted clusters at the end of the file.
+func fillCheckBlocks(body *hclwrite.Body, checkTokens []hclwrite.Tokens) {
+	if len(checkTokens) == 0 {
+		return
+	}
+	body.AppendNewline()
+	hcl.AppendComment(body, commentCheckBlock)
+	hcl.AppendComment(body, commentCheckNote)
+	for _, tokens := range checkTokens {
+		body.AppendNewline()
+		body.AppendUnstructuredTokens(tokens)
+	}
+}
+
+func appendAssert(body *hclwrite.Body, condition, errorMessage string) {
+	assertb := body.AppendNewBlock(nAssert, nil).Body()
+	assertb.SetAttributeRaw(nCondition, hcl.TokensFromExpr(condition))
+	assertb.SetAttributeValue(nErrorMessage, cty.StringVal(errorMessage))
+}
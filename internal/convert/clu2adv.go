@@ -2,13 +2,31 @@ package convert
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
 	"github.com/zclconf/go-cty/cty"
 )
 
+var (
+	sharedTierInstanceSizes = []string{"M2", "M5"}
+	// flexKeptAttrs are the mongodbatlas_cluster attributes and meta-arguments kept in mongodbatlas_flex_cluster.
+	flexKeptAttrs = []string{nProjectID, nName, nTerminationProtection, nTags, nCount, nForEach, "depends_on", "provider"}
+	// clusterRemovedAttrs are the mongodbatlas_cluster root attributes not supported in mongodbatlas_advanced_cluster
+	// and the reason they're removed, it's added as a comment in the converted resource.
+	clusterRemovedAttrs = map[string]string{
+		nBackupEnabled:        "legacy backup is not supported, use " + nCloudBackup + " for Cloud Backup",
+		nEncryptEBSVolumeSrc:  "EBS volumes are always encrypted",
+		nDiskTypeNameSrc:      "Azure disk type is derived from " + nDiskSizeGB,
+		nContainerID:          "it's a computed attribute, use mongodbatlas_network_container to get the container id",
+		nReplicationFactorSrc: "number of nodes is set in " + nRepSpecs,
+	}
+)
+
 type attrVals struct {
 	req map[string]hclwrite.Tokens
 	opt map[string]hclwrite.Tokens
@@ -20,59 +38,157 @@ type attrVals struct {
 // Note: hclwrite.Tokens are used instead of cty.Value so expressions with
 // interpolations like var.region can be preserved.
 // cty.Value only supports literal expressions.
-func ClusterToAdvancedCluster(config []byte, includeMoved bool) ([]byte, error) {
-	var moveLabels []string
+func ClusterToAdvancedCluster(config []byte, opts Options) ([]byte, error) {
+	var (
+		movedResources     []*hclwrite.Block
+		convertedResources []*hclwrite.Block
+		checkTokens        []hclwrite.Tokens
+		newTokens          = make(map[*hclwrite.Block]hclwrite.Tokens)
+		mappers            = make(map[*hclwrite.Block]*pathMapper)
+	)
 	parser, err := hcl.GetParser(config)
 	if err != nil {
 		return nil, err
 	}
 	parserb := parser.Body()
 	for _, block := range parserb.Blocks() {
-		convertedResource, err := convertResource(block)
+		original := block.BuildTokens(nil).Bytes()
+		convertedResource, err := convertResource(block, opts)
 		if err != nil {
 			return nil,
 				err
 		}
-		if includeMoved && convertedResource {
-			if moveLabel := getResourceLabel(block); moveLabel != "" {
-				moveLabels = append(moveLabels, moveLabel)
+		if convertedResource {
+			convertedResources = append(convertedResources, block)
+		}
+		if opts.IncludeMoved && convertedResource && getResourceLabel(block) != "" {
+			movedResources = append(movedResources, block)
+		}
+		if convertedResource && getResourceName(block) == advCluster {
+			mappers[block] = newPathMapper(block, original)
+			if schedule := extractBackupSchedule(block); schedule != nil {
+				newTokens[block] = appendTokensAfter(newTokens[block], schedule.BuildTokens(nil))
+			}
+		}
+		if opts.ExtractLocals && convertedResource {
+			if locals := extractLocals(block); locals != nil {
+				newTokens[block] = appendTokensAfter(newTokens[block], locals.BuildTokens(nil))
 			}
 		}
+		if opts.IncludeCheck && convertedResource && getResourceName(block) == advCluster {
+			if label := getResourceLabel(block); label != "" {
+				checkTokens = append(checkTokens, getCheckTokens(label, original))
+			}
+		}
+		if opts.KeepOriginal && convertedResource {
+			newTokens[block] = appendTokensAfter(newTokens[block], getOriginalTokens(block, original))
+		}
+		if convertedResource {
+			checkRegions(block.Body(), opts.NormalizeRegions)
+		}
 		convertedDataSource := convertDataSource(block)
 		if convertedResource || convertedDataSource {
 			addComments(block, false)
 		}
 	}
-	fillMovedBlocks(parserb, moveLabels)
-	return parser.Bytes(), nil
+	updateLifecycles(parserb, cluster, mappers)
+	var advClusterLabels []string
+	for _, resource := range convertedResources {
+		if getResourceName(resource) == advCluster {
+			advClusterLabels = append(advClusterLabels, getResourceLabel(resource))
+		}
+	}
+	if len(advClusterLabels) > 0 {
+		updateRepSpecIDReferences(parserb, cluster, advClusterLabels)
+	}
+	fillMovedBlocks(parserb, cluster, movedResources, commentRemovedOld)
+	if opts.IncludeRemoved || opts.IncludeImport {
+		fillRemovedImportBlocks(parserb, convertedResources, opts.IncludeRemoved)
+	}
+	fillCheckBlocks(parserb, checkTokens)
+	if opts.UpdateProvider {
+		updateProviderVersion(parserb, opts.IncludeMoved)
+	}
+	return hcl.BytesWithTokensAfter(parser, newTokens), nil
 }
 
-func convertResource(block *hclwrite.Block) (bool, error) {
+func convertResource(block *hclwrite.Block, opts Options) (bool, error) {
 	if block.Type() != resourceType || getResourceName(block) != cluster {
 		return false, nil
 	}
-	setResourceName(block, advCluster)
 	blockb := block.Body()
+	if opts.ConvertFlex && isSharedTierCluster(blockb) {
+		setResourceName(block, flexCluster)
+		if err := processFlexCluster(blockb); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	setResourceName(block, advCluster)
 	if errDyn := checkDynamicBlock(blockb); errDyn != nil {
 		return false, errDyn
 	}
+	warnings := processLegacyRootAttrs(blockb)
 	var err error
 	if isFreeTierCluster(blockb) {
 		err = processFreeTierCluster(blockb)
 	} else {
-		err = processCluster(blockb)
+		err = processCluster(blockb, opts)
 	}
 	if err != nil {
 		return false, err
 	}
+	for _, warning := range warnings {
+		hcl.AppendComment(blockb, warning)
+	}
 	return true, nil
 }
 
+// processLegacyRootAttrs maps deprecated mongodbatlas_cluster root attributes to their current names, and removes
+// the ones not supported in mongodbatlas_advanced_cluster. It returns the warnings for the attributes removed.
+// replication_factor is kept only if it's used as the node count of the default replication_specs.
+func processLegacyRootAttrs(resourceb *hclwrite.Body) []string {
+	var warnings []string
+	useReplicationFactor := !isFreeTierCluster(resourceb) && resourceb.FirstMatchingBlock(nRepSpecs, nil) == nil &&
+		resourceb.FirstMatchingBlock(nDynamic, []string{nRepSpecs}) == nil
+	for _, name := range slices.Sorted(maps.Keys(clusterRemovedAttrs)) {
+		if resourceb.GetAttribute(name) == nil || (name == nReplicationFactorSrc && useReplicationFactor) {
+			continue
+		}
+		resourceb.RemoveAttribute(name)
+		warnings = append(warnings, fmt.Sprintf(commentAttrRemoved, name, clusterRemovedAttrs[name]))
+	}
+	renames := [][2]string{{nProviderBackupEnabledSrc, nCloudBackup}, {nBiConnectorSrc, nBiConnector}}
+	for _, rename := range renames {
+		oldName, newName := rename[0], rename[1]
+		if resourceb.GetAttribute(oldName) == nil {
+			continue
+		}
+		if resourceb.GetAttribute(newName) != nil || resourceb.FirstMatchingBlock(newName, nil) != nil {
+			resourceb.RemoveAttribute(oldName)
+			warnings = append(warnings, fmt.Sprintf(commentAttrRemoved, oldName, newName+" is used instead"))
+			continue
+		}
+		_ = hcl.MoveAttr(resourceb, resourceb, oldName, newName, errRoot)
+	}
+	return warnings
+}
+
 func isFreeTierCluster(resourceb *hclwrite.Body) bool {
 	providerName, _ := hcl.GetAttrString(resourceb.GetAttribute(nProviderName))
 	return providerName == nTenant
 }
 
+// isSharedTierCluster returns true for M2 and M5 clusters, they're replaced by flex clusters. M0 is not included.
+func isSharedTierCluster(resourceb *hclwrite.Body) bool {
+	instanceSizeAttr := resourceb.GetAttribute(nInstanceSizeSrc)
+	if !isFreeTierCluster(resourceb) || instanceSizeAttr == nil {
+		return false
+	}
+	instanceSize, _ := hcl.GetAttrString(instanceSizeAttr)
+	return slices.Contains(sharedTierInstanceSizes, instanceSize)
+}
+
 func convertDataSource(block *hclwrite.Block) bool {
 	if block.Type() != dataSourceType {
 		return false
@@ -88,23 +204,129 @@ func convertDataSource(block *hclwrite.Block) bool {
 	return false
 }
 
-func fillMovedBlocks(body *hclwrite.Body, moveLabels []string) {
-	if len(moveLabels) == 0 {
+// fillMovedBlocks adds moved blocks from the original resource type to the type of the converted resources,
+// note is added after the header comment.
+func fillMovedBlocks(body *hclwrite.Body, fromType string, resources []*hclwrite.Block, note string) {
+	if len(resources) == 0 {
 		return
 	}
 	body.AppendNewline()
 	hcl.AppendComment(body, commentMovedBlock)
-	hcl.AppendComment(body, commentRemovedOld)
+	hcl.AppendComment(body, note)
 	body.AppendNewline()
-	for i, moveLabel := range moveLabels {
+	for i, resource := range resources {
+		moveLabel := getResourceLabel(resource)
 		block := body.AppendNewBlock(nMoved, nil)
 		blockb := block.Body()
-		blockb.SetAttributeRaw(nFrom, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", cluster, moveLabel)))
-		blockb.SetAttributeRaw(nTo, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", advCluster, moveLabel)))
-		if i < len(moveLabels)-1 {
+		blockb.SetAttributeRaw(nFrom, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", fromType, moveLabel)))
+		blockb.SetAttributeRaw(nTo, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", getResourceName(resource), moveLabel)))
+		if i < len(resources)-1 {
+			body.AppendNewline()
+		}
+	}
+}
+
+// fillRemovedImportBlocks adds removed blocks to forget the old clusters without destroying them if includeRemoved,
+// and import blocks to bring them in as advanced clusters.
+func fillRemovedImportBlocks(body *hclwrite.Body, resources []*hclwrite.Block, includeRemoved bool) {
+	if len(resources) == 0 {
+		return
+	}
+	body.AppendNewline()
+	if includeRemoved {
+		hcl.AppendComment(body, commentRemovedBlock)
+		hcl.AppendComment(body, commentRemovedNote)
+	} else {
+		hcl.AppendComment(body, commentImportBlock)
+		hcl.AppendComment(body, commentImportNote)
+	}
+	for _, resource := range resources {
+		label := getResourceLabel(resource)
+		if label == "" {
+			continue
+		}
+		body.AppendNewline()
+		if issue := getImportIssue(resource.Body(), label); issue != "" {
+			hcl.AppendComment(body, issue)
+			if includeRemoved {
+				hcl.AppendComment(body, fmt.Sprintf(commentRemovedNotGenerated, label))
+			}
+			continue
+		}
+		if includeRemoved {
+			removedb := body.AppendNewBlock(nRemoved, nil).Body()
+			removedb.SetAttributeRaw(nFrom, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", cluster, label)))
+			lifecycleb := removedb.AppendNewBlock(nLifecycle, nil).Body()
+			lifecycleb.SetAttributeValue(nDestroy, cty.False)
 			body.AppendNewline()
 		}
+		fillImportBlock(body, resource.Body(), getResourceName(resource), label)
+	}
+}
+
+// getImportIssue returns why the import block of a cluster can't be generated, or an empty string if it can.
+// Resources with count are not supported, and the import id needs project_id and name.
+func getImportIssue(resourceb *hclwrite.Body, label string) string {
+	if resourceb.GetAttribute(nCount) != nil {
+		return fmt.Sprintf(commentImportCount, label, nCount)
+	}
+	if getImportID(resourceb) == nil {
+		return fmt.Sprintf(commentImportNotFound, label, nProjectID, nName)
+	}
+	return ""
+}
+
+// fillImportBlock adds the import block of an advanced or flex cluster, getImportIssue must be checked before.
+// Resources with for_each are imported with for_each in the import block.
+func fillImportBlock(body, resourceb *hclwrite.Body, resType, label string) {
+	idTokens := getImportID(resourceb)
+	if !isImportIDKnownInPlan(resourceb) {
+		hcl.AppendComment(body, fmt.Sprintf(commentImportUnknown, label))
+	}
+	importb := body.AppendNewBlock(nImport, nil).Body()
+	to := fmt.Sprintf("%s.%s", resType, label)
+	if forEach := resourceb.GetAttribute(nForEach); forEach != nil {
+		importb.SetAttributeRaw(nForEach, forEach.Expr().BuildTokens(nil))
+		to += fmt.Sprintf("[%s.%s]", nEach, nKey)
+	}
+	importb.SetAttributeRaw(nTo, hcl.TokensFromExpr(to))
+	importb.SetAttributeRaw(nID, idTokens)
+}
+
+// getImportID returns the import id of an advanced cluster with format <project_id>-<name>,
+// or nil if any of them is not set. References to variables or other values are kept as expressions.
+func getImportID(resourceb *hclwrite.Body) hclwrite.Tokens {
+	projectID := hcl.GetAttrTemplatePart(resourceb.GetAttribute(nProjectID))
+	name := hcl.GetAttrTemplatePart(resourceb.GetAttribute(nName))
+	if projectID == "" || name == "" {
+		return nil
+	}
+	return hcl.TokensFromExpr(`"` + projectID + "-" + name + `"`)
+}
+
+// isImportIDKnownInPlan checks that the import id only references values known during plan,
+// e.g. literals, variables, locals or each. References to other resources or data sources can be unknown.
+func isImportIDKnownInPlan(resourceb *hclwrite.Body) bool {
+	knownRoots := []string{nVar, nLocal, nEach}
+	for _, name := range []string{nProjectID, nName} {
+		expr, err := hcl.ParseAttrExpr(resourceb.GetAttribute(name))
+		if err != nil {
+			return false
+		}
+		for _, traversal := range expr.Variables() {
+			if !slices.Contains(knownRoots, traversal.RootName()) {
+				return false
+			}
+		}
 	}
+	return true
+}
+
+// getOriginalTokens returns the original definition of a resource as comments.
+func getOriginalTokens(resource *hclwrite.Block, original []byte) hclwrite.Tokens {
+	name := fmt.Sprintf("%s.%s", cluster, getResourceLabel(resource))
+	tokens := hcl.TokensComment(fmt.Sprintf(commentOriginal, name))
+	return append(tokens, hcl.TokensCommentedOut(original)...)
 }
 
 // createDefaultRepSpec creates a default replication_specs for clusters without any
@@ -124,7 +346,9 @@ func createDefaultRepSpec(resourceb *hclwrite.Body, root attrVals) error {
 	if instanceSizeTokens, found := root.req[nInstanceSizeSrc]; found {
 		electableSpecb.SetAttributeRaw(nInstanceSize, instanceSizeTokens)
 	}
-	electableSpecb.SetAttributeValue(nNodeCount, cty.NumberIntVal(valDefaultNodeCount))
+	if err := hcl.MoveAttr(resourceb, electableSpecb, nReplicationFactorSrc, nNodeCount, errRoot); err != nil {
+		electableSpecb.SetAttributeValue(nNodeCount, cty.NumberIntVal(valDefaultNodeCount))
+	}
 	configb.SetAttributeRaw(nElectableSpecs, hcl.TokensObject(electableSpecb))
 
 	repSpecsb := hclwrite.NewEmptyFile().Body()
@@ -159,8 +383,65 @@ func processFreeTierCluster(resourceb *hclwrite.Body) error {
 	return nil
 }
 
+// processFlexCluster converts a shared-tier cluster to a flex cluster, attributes not supported are removed.
+func processFlexCluster(resourceb *hclwrite.Body) error {
+	settingsb := hclwrite.NewEmptyFile().Body()
+	if err := hcl.MoveAttr(resourceb, settingsb, nBackingProviderName, nBackingProviderName, errFlexCluster); err != nil {
+		return err
+	}
+	if err := hcl.MoveAttr(resourceb, settingsb, nRegionNameSrc, nRegionName, errFlexCluster); err != nil {
+		return err
+	}
+	resourceb.RemoveAttribute(nProviderName)
+	resourceb.RemoveAttribute(nInstanceSizeSrc)
+	return fillFlexCluster(resourceb, settingsb)
+}
+
+// fillFlexCluster sets provider_settings and tags of a flex cluster, other attributes and blocks not supported
+// in flex clusters are removed and listed in a comment, followed by the extra comments if any.
+func fillFlexCluster(resourceb, settingsb *hclwrite.Body, comments ...string) error {
+	var removed []string
+	for name := range resourceb.Attributes() {
+		if !slices.Contains(flexKeptAttrs, name) {
+			removed = append(removed, name)
+			resourceb.RemoveAttribute(name)
+		}
+	}
+	resourceb.SetAttributeRaw(nProviderSettings, hcl.TokensObject(settingsb))
+	if err := fillTagsLabelsOpt(resourceb, nTags); err != nil {
+		return err
+	}
+	for _, block := range resourceb.Blocks() {
+		if block.Type() == nLifecycle { // move to the end so it's after the new attributes
+			resourceb.RemoveBlock(block)
+			resourceb.AppendNewline()
+			resourceb.AppendBlock(block)
+			continue
+		}
+		name := block.Type()
+		if name == nDynamic && len(block.Labels()) == 1 {
+			name = block.Labels()[0]
+		}
+		removed = append(removed, name)
+		resourceb.RemoveBlock(block)
+	}
+	hcl.RemoveExtraBlankLines(resourceb)
+	if len(removed) > 0 {
+		slices.Sort(removed)
+		comments = append([]string{fmt.Sprintf(commentFlexRemoved, strings.Join(slices.Compact(removed), ", "))},
+			comments...)
+	}
+	if len(comments) > 0 {
+		resourceb.AppendNewline()
+	}
+	for _, comment := range comments {
+		hcl.AppendComment(resourceb, comment)
+	}
+	return nil
+}
+
 // fillCluster is the entry point to convert clusters with replications_specs (all but free tier)
-func processCluster(resourceb *hclwrite.Body) error {
+func processCluster(resourceb *hclwrite.Body, opts Options) error {
 	root, errRoot := popRootAttrs(resourceb)
 	if errRoot != nil {
 		return errRoot
@@ -168,13 +449,13 @@ func processCluster(resourceb *hclwrite.Body) error {
 	resourceb.RemoveAttribute(nNumShards) // num_shards in root is not relevant, only in replication_specs
 	// ok to fail as cloud_backup is optional
 	_ = hcl.MoveAttr(resourceb, resourceb, nCloudBackup, nBackupEnabled, errRepSpecs)
-	if err := processRepSpecsCluster(resourceb, root); err != nil {
+	if err := processRepSpecsCluster(resourceb, root, opts); err != nil {
 		return err
 	}
 	return processCommonOptionalBlocks(resourceb)
 }
 
-func processRepSpecsCluster(resourceb *hclwrite.Body, root attrVals) error {
+func processRepSpecsCluster(resourceb *hclwrite.Body, root attrVals, opts Options) error {
 	d, err := processRepSpecsClusterWithDynamicBlock(resourceb, root)
 	if err != nil {
 		return err
@@ -196,7 +477,7 @@ func processRepSpecsCluster(resourceb *hclwrite.Body, root attrVals) error {
 		resourceb.SetAttributeRaw(nRepSpecs, dConfig.tokens)
 		return nil
 	}
-	hasVariableShards := hasVariableNumShards(repSpecBlocks)
+	useTokens := hasVariableNumShards(repSpecBlocks) || (opts.CompactShards && hasMultipleNumShards(repSpecBlocks))
 	var resultTokens []hclwrite.Tokens
 	var resultBodies []*hclwrite.Body
 	for _, block := range repSpecBlocks {
@@ -210,8 +491,8 @@ func processRepSpecsCluster(resourceb *hclwrite.Body, root attrVals) error {
 		if errConfig := processRegionConfigs(specb, specbSrc, root); errConfig != nil {
 			return errConfig
 		}
-		if hasVariableShards {
-			resultTokens = append(resultTokens, processNumShardsWhenSomeIsVariable(shardsAttr, specb))
+		if useTokens {
+			resultTokens = append(resultTokens, processNumShardsTokens(shardsAttr, specb, opts.CompactShards))
 			continue
 		}
 		shardsVal, err := hcl.GetAttrInt(shardsAttr, errNumShards)
@@ -222,7 +503,7 @@ func processRepSpecsCluster(resourceb *hclwrite.Body, root attrVals) error {
 			resultBodies = append(resultBodies, specb)
 		}
 	}
-	if hasVariableShards {
+	if useTokens {
 		resourceb.SetAttributeRaw(nRepSpecs, hcl.TokensFuncConcat(resultTokens...))
 	} else {
 		resourceb.SetAttributeRaw(nRepSpecs, hcl.TokensArray(resultBodies))
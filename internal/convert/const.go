@@ -7,6 +7,15 @@ const (
 	advCluster          = "mongodbatlas_advanced_cluster"
 	clusterPlural       = "mongodbatlas_clusters"
 	advClusterPlural    = "mongodbatlas_advanced_clusters"
+	flexCluster         = "mongodbatlas_flex_cluster"
+	flexClusterPlural   = "mongodbatlas_flex_clusters"
+	backupSchedule      = "mongodbatlas_cloud_backup_schedule"
+	serverless          = "mongodbatlas_serverless_instance"
+	serverlessPlural    = "mongodbatlas_serverless_instances"
+	providerLocalName   = "mongodbatlas"
+	providerTypePrefix  = "mongodbatlas_"
+	providerSource      = "mongodb/mongodbatlas"
+	providerConstraint  = "~> 2.0"
 	valClusterType      = "REPLICASET"
 	valMaxPriority      = 7
 	valMinPriority      = 0
@@ -16,6 +25,8 @@ const (
 	errPriority         = "setting " + nPriority
 	errNumShards        = "setting " + nNumShards
 	errRoot             = "setting root attributes"
+	errFlexCluster      = "flex cluster (because " + nTenant + " with shared-tier instance size)"
+	errServerless       = "serverless instance"
 
 	commentGeneratedBy       = "Generated by atlas-cli-plugin-terraform."
 	commentConfirmReferences = "Please review the changes and confirm that references to this resource are updated."
@@ -23,6 +34,66 @@ const (
 	commentMovedBlock        = "Moved blocks"
 	commentRemovedOld        = "Note: Remember to remove or comment out the old cluster definitions."
 	commentPriorityFor       = "Regions must be sorted by priority in descending order."
+	commentRemovedBlock      = "Removed and import blocks"
+	commentRemovedNote       = "Note: Terraform 1.7 or later is required, " +
+		"the old clusters are removed from the state without being destroyed."
+	commentImportBlock = "Import blocks"
+	commentImportNote  = "Note: Remember to remove the old clusters from the state before applying, " +
+		"e.g. with terraform state rm."
+	commentImportNotFound = "Import block not generated for %s: %s and %s must be set to build the import id."
+	commentImportCount    = "Import block not generated for %s: " +
+		"resources with %s need an import block for each instance."
+	commentRemovedNotGenerated = "Removed block not generated for %s as it can't be imported, " +
+		"migrate its state manually or with moved blocks."
+	commentImportUnknown = "Import id of %s can't be derived statically, it must be known during plan."
+	commentStateScript   = "State migration from " + cluster + " to " + advCluster +
+		", run it in the Terraform directory."
+	commentStateNotResolved     = "import id can't be resolved as %v, complete and uncomment the commands."
+	commentStateKeysNotResolved = "instances can't be resolved as %s can't be evaluated statically, " +
+		"add the commands for each instance."
+	commentOriginal         = "Original definition of %s, it can be deleted after the migration is complete:"
+	commentFlexRemoved      = "Attributes not supported in " + flexCluster + " were removed: %s."
+	commentContinuousBackup = nContinuousBackup + " was removed, flex clusters have daily snapshots " +
+		"instead of continuous cloud backup."
+	commentBackupRemoved = "Attributes of " + nSnapshotBackupPolicy + " not supported in " + backupSchedule +
+		" were removed: %s."
+	commentBackupDynamic   = "dynamic %s blocks in " + nSnapshotBackupPolicy + " can't be converted, add them manually."
+	commentBackupFrequency = nPolicyItem + " with " + nFrequencyType + " %s can't be converted, add it manually."
+	commentBackupDuplicate = "only one " + nPolicyItem + " with " + nFrequencyType + " %s is supported, " +
+		"the others were removed."
+	commentBackupDisabled = backupSchedule + " requires " + nBackupEnabled + " = true in %s."
+	commentAttrRemoved    = "%s was removed, %s."
+	commentZoneIDUpdated  = "Updated by atlas-cli-plugin-terraform, references to " + nRepSpecs + " id were changed to " +
+		nZoneID + ", please review the changes."
+	commentObjectUpdated = "Updated by atlas-cli-plugin-terraform, references to nested attributes of " + advCluster +
+		" were changed to the Provider 2.0.0 schema, please review the changes."
+	commentNestedObjectUpdated = "Updated by atlas-cli-plugin-terraform, references to nested attributes " +
+		"were changed to the Provider 2.0.0 schema, please review the changes."
+	commentForReferences = "for expressions over " + advCluster + " can't be updated to the Provider 2.0.0 schema, " +
+		"remove the [0] index of nested attributes like " + nElectableSpecs + " in their iteration variables."
+	commentLifecycleRemoved = "%s was removed from %s as it doesn't have an equivalent in " + advCluster +
+		" 2.0.0, add the new paths manually if needed."
+	commentLifecycleReview    = "%s in %s can't be converted to " + advCluster + " 2.0.0, please review it."
+	commentProviderNotUpdated = "%s version constraint was not updated to Provider 2.X.X because %v, " +
+		"please update it manually."
+	commentRequiredVersion = nRequiredVersion + " allows Terraform versions older than 1.8, " +
+		"which is required by the moved blocks across resource types."
+	commentRuleRemoved    = "%s was removed by rule %s."
+	commentRuleApplied    = "Updated by atlas-cli-plugin-terraform with rule %s, please review the changes."
+	commentRuleReferences = "Updated by atlas-cli-plugin-terraform, references to types renamed by rules were changed, " +
+		"please review the changes."
+	commentRuleMovedNote = "Note: moved blocks across resource types require Terraform 1.8 or later " +
+		"and support in the provider to move from the old type."
+	commentRegionInvalid    = "%s %s is not a valid %s region in Atlas."
+	commentRegionSuggestion = "%s %s is not a valid %s region in Atlas, did you mean %s?"
+	commentRegionProvider   = "%s %s is not a valid %s region in Atlas, it's a region of %s."
+	commentRegionNormalized = "%s %s was changed to the Atlas region name %s."
+	commentSchemaNotFound   = "%s is not in the provider schema snapshot, its blocks were not converted, " +
+		"please review them."
+	commentCheckBlock = "Check blocks"
+	commentCheckNote  = "Note: Terraform 1.5 or later is required, " +
+		"they warn if the topology of the converted clusters changes."
+	commentCheckNotGenerated = "Check block not generated for %s: %v."
 
 	nRepSpecs                   = "replication_specs"
 	nConfig                     = "region_configs"
@@ -33,6 +104,7 @@ const (
 	nAdvConfig                  = "advanced_configuration"
 	nPinnedFCV                  = "pinned_fcv"
 	nBiConnector                = "bi_connector_config"
+	nBiConnectorSrc             = "bi_connector"
 	nElectableSpecs             = "electable_specs"
 	nAutoScaling                = "auto_scaling"
 	nAnalyticsAutoScaling       = "analytics_auto_scaling"
@@ -49,6 +121,9 @@ const (
 	nNumShards                  = "num_shards"
 	nBackupEnabled              = "backup_enabled"
 	nCloudBackup                = "cloud_backup"
+	nProviderBackupEnabledSrc   = "provider_backup_enabled"
+	nEncryptEBSVolumeSrc        = "provider_encrypt_ebs_volume"
+	nDiskTypeNameSrc            = "provider_disk_type_name"
 	nDiskSizeGB                 = "disk_size_gb"
 	nDiskGBEnabledSrc           = "auto_scaling_disk_gb_enabled"
 	nComputeEnabledSrc          = "auto_scaling_compute_enabled"
@@ -77,9 +152,53 @@ const (
 	nDynamic                    = "dynamic"
 	nForEach                    = "for_each"
 	nContent                    = "content"
+	nIterator                   = "iterator"
 	nRegion                     = "region"
 	nSpec                       = "spec"
 	nFailIndexKeyTooLong        = "fail_index_key_too_long"
 	nDefaultReadConcern         = "default_read_concern"
 	nTenant                     = "TENANT"
+	nLocals                     = "locals"
+	nLocal                      = "local"
+	nVar                        = "var"
+	nEach                       = "each"
+	nFor                        = "for"
+	nIn                         = "in"
+	nCount                      = "count"
+	nSelf                       = "self"
+	nRemoved                    = "removed"
+	nImport                     = "import"
+	nCheck                      = "check"
+	nProviderSettings           = "provider_settings"
+	nTerminationProtection      = "termination_protection_enabled"
+	nContinuousBackup           = "continuous_backup_enabled"
+	nSnapshotBackupPolicy       = "snapshot_backup_policy"
+	nPolicies                   = "policies"
+	nPolicyItem                 = "policy_item"
+	nFrequencyType              = "frequency_type"
+	nClusterName                = "cluster_name"
+	nReplicationSpecID          = "replication_spec_id"
+	nConnectionStrings          = "connection_strings"
+	nUseRepSpecPerShard         = "use_replication_spec_per_shard"
+	nSettingsBackingProvider    = "provider_settings_backing_provider_name"
+	nSettingsProviderName       = "provider_settings_provider_name"
+	nSettingsRegionName         = "provider_settings_region_name"
+	nAssert                     = "assert"
+	nCondition                  = "condition"
+	nErrorMessage               = "error_message"
+	nLifecycle                  = "lifecycle"
+	nTerraform                  = "terraform"
+	nModule                     = "module"
+	nRequiredProviders          = "required_providers"
+	nRequiredVersion            = "required_version"
+	nSource                     = "source"
+	nVersion                    = "version"
+	nIgnoreChanges              = "ignore_changes"
+	nReplaceTriggeredBy         = "replace_triggered_by"
+	nPrecondition               = "precondition"
+	nPostcondition              = "postcondition"
+	nDestroy                    = "destroy"
+	nID                         = "id"
+	nProjectID                  = "project_id"
+	nName                       = "name"
 )
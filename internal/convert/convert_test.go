@@ -3,9 +3,11 @@ package convert_test
 import (
 	"encoding/json"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
 	"github.com/sebdah/goldie/v2"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -15,11 +17,15 @@ import (
 // runConvertTests runs common conversion tests with the given test directory and convert function
 func runConvertTests(t *testing.T, cmdName string, convert func(testName string, inConfig []byte) ([]byte, error)) {
 	t.Helper()
-	const (
-		inSuffix    = ".in.tf"
-		outSuffix   = ".out.tf"
-		errFilename = "errors.json"
-	)
+	runConvertTestsWithSuffix(t, cmdName, ".in.tf", ".out.tf", convert)
+}
+
+// runConvertTestsWithSuffix runs common conversion tests for input and output files with the given suffixes,
+// e.g. .in.tfstate and .out.tfstate
+func runConvertTestsWithSuffix(t *testing.T, cmdName, inSuffix, outSuffix string,
+	convert func(testName string, inConfig []byte) ([]byte, error)) {
+	t.Helper()
+	const errFilename = "errors.json"
 	root := filepath.Join("testdata", cmdName)
 	fs := afero.NewOsFs()
 	errMap := make(map[string]string)
@@ -57,3 +63,49 @@ func runConvertTests(t *testing.T, cmdName string, convert func(testName string,
 	}
 	assert.Empty(t, unusedErrors, "some errors are not being used")
 }
+
+// TestConverters runs the golden file tests of all the converters, the testdata directory of each converter is
+// its first alias.
+func TestConverters(t *testing.T) {
+	for i := range convert.Converters {
+		c := &convert.Converters[i]
+		t.Run(c.Name, func(t *testing.T) {
+			runConvertTests(t, c.Aliases[0], func(testName string, inConfig []byte) ([]byte, error) {
+				return c.Convert(inConfig, getOptions(c, testName))
+			})
+		})
+	}
+}
+
+// getOptions returns the conversion options enabled by the test name, e.g. includeMoved_single enables IncludeMoved.
+func getOptions(c *convert.Converter, testName string) convert.Options {
+	var opts convert.Options
+	for _, flag := range c.Flags {
+		if strings.Contains(testName, flag.Name) {
+			flag.Enable(&opts)
+		}
+	}
+	return opts
+}
+
+// TestAdvancedClusterToV2Idempotent checks that converting the advancedClusterToV2 golden files again doesn't
+// change them, e.g. comments are not added twice.
+func TestAdvancedClusterToV2Idempotent(t *testing.T) {
+	i := slices.IndexFunc(convert.Converters, func(c convert.Converter) bool { return c.Name == "advancedClusterToV2" })
+	require.GreaterOrEqual(t, i, 0)
+	c := &convert.Converters[i]
+	fs := afero.NewOsFs()
+	outputFiles, err := afero.Glob(fs, filepath.Join("testdata", c.Aliases[0], "*.out.tf"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, outputFiles)
+	for _, outputFile := range outputFiles {
+		testName := strings.TrimSuffix(filepath.Base(outputFile), ".out.tf")
+		t.Run(testName, func(t *testing.T) {
+			config, err := afero.ReadFile(fs, outputFile)
+			require.NoError(t, err)
+			outConfig, err := c.Convert(config, getOptions(c, testName))
+			require.NoError(t, err)
+			assert.Equal(t, string(config), string(outConfig))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package convert
+
+import "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/flags"
+
+// OptionFlag is a CLI flag of a converter that enables a conversion option.
+type OptionFlag struct {
+	Enable    func(opts *Options)
+	Name      string
+	Shorthand string
+	Usage     string // description in the CLI help
+	Doc       string // description in the command documentation, in Markdown
+}
+
+// Converter is a conversion of Terraform configuration files. The CLI commands, the options in their documentation
+// and the golden file tests are generated from the converters in Converters, so adding a conversion only requires
+// adding it there.
+type Converter struct {
+	Convert func(config []byte, opts Options) ([]byte, error)
+	// StateMigrations returns the state migrations of the converted resources, --stateScript is added if set.
+	StateMigrations func(config []byte, modulePath string, opts Options) ([]StateMigration, error)
+	Name            string // command name, e.g. clusterToAdvancedCluster
+	Short           string
+	Long            string
+	Aliases         []string // the first one is also the name of the documentation file and the testdata directory
+	SourceTypes     []string // resource and data source types converted
+	TargetTypes     []string // resource and data source types generated
+	Flags           []OptionFlag
+	ExclusiveFlags  [][]string // flags that can't be used together
+	Verify          bool       // --verify is added to check the converted clusters have the same topology
+}
+
+var (
+	flagIncludeMoved = OptionFlag{
+		Name: flags.IncludeMoved, Shorthand: flags.IncludeMovedShort,
+		Usage:  "include moved blocks in the output file",
+		Doc:    "Include the `moved blocks` in the output file",
+		Enable: func(opts *Options) { opts.IncludeMoved = true },
+	}
+	flagCompactShards = OptionFlag{
+		Name: flags.CompactShards, Shorthand: flags.CompactShardsShort,
+		Usage: "use a for expression instead of repeating replication_specs for each shard",
+		Doc: "Use a `for` expression instead of repeating the `replication_specs` object for each shard when " +
+			"`num_shards` is a number greater than 1, see [Compact shards](#compact-shards)",
+		Enable: func(opts *Options) { opts.CompactShards = true },
+	}
+	flagExtractLocals = OptionFlag{
+		Name: flags.ExtractLocals, Shorthand: flags.ExtractLocalsShort,
+		Usage: "move generated replication_specs expressions to locals",
+		Doc: "Move the `replication_specs` expressions generated from `dynamic` blocks to `locals`, " +
+			"see [Extract locals](#extract-locals)",
+		Enable: func(opts *Options) { opts.ExtractLocals = true },
+	}
+	flagKeepOriginal = OptionFlag{
+		Name: flags.KeepOriginal, Shorthand: flags.KeepOriginalShort,
+		Usage:  "keep a commented out copy of the original resources in the output file",
+		Doc:    "Keep a commented out copy of the original `mongodbatlas_cluster` resources after the converted ones",
+		Enable: func(opts *Options) { opts.KeepOriginal = true },
+	}
+	flagIncludeRemoved = OptionFlag{
+		Name:  flags.IncludeRemoved,
+		Usage: "include removed and import blocks in the output file instead of moved blocks",
+		Doc: "Include `removed` and `import` blocks in the output file instead of `moved` blocks, " +
+			"see [Removed and import blocks](#removed-and-import-blocks). It can't be used together with `--includeMoved`",
+		Enable: func(opts *Options) { opts.IncludeRemoved = true },
+	}
+	flagIncludeImport = OptionFlag{
+		Name: flags.IncludeImport, Shorthand: flags.IncludeImportShort,
+		Usage: "include import blocks in the output file instead of moved blocks",
+		Doc: "Include only `import` blocks in the output file instead of `moved` blocks, " +
+			"see [Removed and import blocks](#removed-and-import-blocks). " +
+			"It can't be used together with `--includeMoved` or `--includeRemoved`",
+		Enable: func(opts *Options) { opts.IncludeImport = true },
+	}
+	flagIncludeCheck = OptionFlag{
+		Name:   flags.IncludeCheck,
+		Usage:  "include check blocks asserting the topology of the converted clusters",
+		Doc:    "Include `check` blocks asserting the topology of the converted clusters, see [Check blocks](#check-blocks)",
+		Enable: func(opts *Options) { opts.IncludeCheck = true },
+	}
+	flagConvertFlex = OptionFlag{
+		Name:  flags.ConvertFlex,
+		Usage: "convert shared-tier clusters (M2 and M5) to mongodbatlas_flex_cluster",
+		Doc: "Convert shared-tier clusters (`M2` and `M5`) to `mongodbatlas_flex_cluster` instead of " +
+			"`mongodbatlas_advanced_cluster`, see [Flex clusters](#flex-clusters)",
+		Enable: func(opts *Options) { opts.ConvertFlex = true },
+	}
+	flagUpdateProvider = OptionFlag{
+		Name:  flags.UpdateProvider,
+		Usage: "update the mongodbatlas version constraint in required_providers to provider 2.0.0",
+		Doc: "Update the `mongodbatlas` version constraint in `required_providers` to a range compatible with " +
+			"Provider 2.0.0, see [Provider version](#provider-version)",
+		Enable: func(opts *Options) { opts.UpdateProvider = true },
+	}
+	flagNormalizeRegions = OptionFlag{
+		Name:  flags.NormalizeRegions,
+		Usage: "change cloud-native region names like us-east-1 to Atlas region names like US_EAST_1",
+		Doc: "Change cloud-native region names like `us-east-1` to Atlas region names like `US_EAST_1` instead of " +
+			"adding a comment, see [Region names](./regions.md)",
+		Enable: func(opts *Options) { opts.NormalizeRegions = true },
+	}
+)
+
+// Converters are the conversions available as CLI commands, in the order they're shown in the help.
+var Converters = []Converter{
+	{
+		Name:  "clusterToAdvancedCluster",
+		Short: "Convert cluster to advanced_cluster preview provider 2.0.0",
+		Long: "Convert a Terraform configuration from mongodbatlas_cluster to " +
+			"mongodbatlas_advanced_cluster preview provider 2.0.0",
+		Aliases:         []string{"clu2adv"},
+		SourceTypes:     []string{cluster, clusterPlural},
+		TargetTypes:     []string{advCluster, advClusterPlural, flexCluster, backupSchedule},
+		Convert:         ClusterToAdvancedCluster,
+		StateMigrations: ClusterStateMigrations,
+		Flags: []OptionFlag{
+			flagIncludeMoved, flagCompactShards, flagExtractLocals, flagKeepOriginal, flagIncludeRemoved,
+			flagIncludeImport, flagIncludeCheck, flagConvertFlex, flagUpdateProvider, flagNormalizeRegions,
+		},
+		ExclusiveFlags: [][]string{{flags.IncludeMoved, flags.IncludeRemoved, flags.IncludeImport}},
+		Verify:         true,
+	},
+	{
+		Name:  "advancedClusterToV2",
+		Short: "Convert advanced_cluster from provider version 1 to 2",
+		Long: "Convert a Terraform configuration from mongodbatlas_advanced_cluster in provider version 1.X.X (SDKv2)" +
+			" to version 2.X.X (TPF - Terraform Plugin Framework)",
+		Aliases:     []string{"adv2v2"},
+		SourceTypes: []string{advCluster, advClusterPlural},
+		TargetTypes: []string{advCluster, advClusterPlural},
+		Convert:     AdvancedClusterToV2,
+		Flags: []OptionFlag{
+			flagCompactShards, flagExtractLocals, flagIncludeCheck, flagUpdateProvider, flagNormalizeRegions,
+		},
+		Verify: true,
+	},
+	{
+		Name:        "serverlessToFlex",
+		Short:       "Convert serverless_instance to flex_cluster",
+		Long:        "Convert a Terraform configuration from mongodbatlas_serverless_instance to mongodbatlas_flex_cluster",
+		Aliases:     []string{"serverless2flex"},
+		SourceTypes: []string{serverless, serverlessPlural},
+		TargetTypes: []string{flexCluster, flexClusterPlural},
+		Convert:     ServerlessToFlex,
+		Flags:       []OptionFlag{flagIncludeMoved, flagNormalizeRegions},
+	},
+	{
+		Name:  "resourcesToV2",
+		Short: "Convert blocks to nested attributes in mongodbatlas resources for provider 2.0.0",
+		Long: "Convert the blocks that are nested attributes in provider 2.0.0 in all mongodbatlas resources and " +
+			"data sources, using the embedded provider schema",
+		Aliases:     []string{"res2v2"},
+		SourceTypes: []string{"mongodbatlas_*"}, // any type in the embedded schema except advanced_cluster
+		TargetTypes: []string{"mongodbatlas_*"},
+		Convert:     ResourcesToV2,
+		Flags:       []OptionFlag{flagUpdateProvider},
+	},
+}
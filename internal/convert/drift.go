@@ -0,0 +1,226 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// driftSkippedAttrs are the resource root attributes and meta-arguments not compared with the state.
+var driftSkippedAttrs = []string{nCount, nForEach, "depends_on", "provider", "timeouts", "delete_on_create_timeout"}
+
+// PredictDrift compares the literal values of the mongodbatlas_advanced_cluster resources in a converted
+// configuration with the attributes of the same clusters in a Terraform state file (format version 4),
+// and returns the fields where the first plan would show a difference.
+// The clusters in state can be mongodbatlas_cluster or mongodbatlas_advanced_cluster in any provider version.
+// modulePath is the optional module address of the configuration file, e.g. module.db.
+// Values that can't be evaluated statically, e.g. variables, and attributes not in state are not compared.
+func PredictDrift(config, stateContent []byte, modulePath string) ([]string, error) {
+	blocks, err := getResourceBodies(config)
+	if err != nil {
+		return nil, err
+	}
+	var state tfState
+	if err := json.Unmarshal(stateContent, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse Terraform state file: %w", err)
+	}
+	if state.Version != stateVersion {
+		return nil, fmt.Errorf("state format version %d is not supported, only version %d", state.Version, stateVersion)
+	}
+	var ret []string
+	for _, block := range blocks {
+		if block.Labels[0] != advCluster {
+			continue
+		}
+		res := findStateResource(&state, modulePath, block.Labels[1])
+		if res == nil {
+			continue // new resource, it will be created
+		}
+		for _, instance := range res.Instances {
+			address := getStateAddress(res, advCluster) + getStateInstanceKey(instance)
+			attrs, err := getStateAdvClusterAttrs(res.Type, instance)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", address, err)
+			}
+			var diffs []string
+			for name, attr := range block.Body.Attributes {
+				if stateVal, found := attrs[name]; found && !slices.Contains(driftSkippedAttrs, name) {
+					diffs = append(diffs, diffConfigExpr(attr.Expr, stateVal, name)...)
+				}
+			}
+			sort.Strings(diffs)
+			for _, diff := range diffs {
+				ret = append(ret, fmt.Sprintf("%s: %s", address, diff))
+			}
+		}
+	}
+	return ret, nil
+}
+
+// findStateResource returns the managed cluster with the given name in the module, both mongodbatlas_cluster and
+// mongodbatlas_advanced_cluster are considered as moved blocks can change the resource type.
+func findStateResource(state *tfState, modulePath, name string) *tfResource {
+	var found *tfResource
+	for i := range state.Resources {
+		res := &state.Resources[i]
+		if res.Mode != stateModeManaged || res.Module != modulePath || res.Name != name {
+			continue
+		}
+		if res.Type == advCluster {
+			return res
+		}
+		if res.Type == cluster {
+			found = res
+		}
+	}
+	return found
+}
+
+// getStateAdvClusterAttrs returns the attributes of a cluster instance in advanced_cluster 2.0.0 schema.
+func getStateAdvClusterAttrs(resType string, instance map[string]json.RawMessage) (attrMap, error) {
+	attrs, err := decodeAttrs(instance[nStateAttributes])
+	if err != nil {
+		return nil, err
+	}
+	var version int
+	_ = json.Unmarshal(instance[nStateSchemaVersion], &version)
+	switch {
+	case resType == cluster:
+		attrs = migrateClusterAttrs(attrs)
+	case version < advClusterSchemaV2:
+		attrs = migrateAdvClusterAttrs(attrs)
+	default:
+		return attrs, nil
+	}
+	for _, name := range []string{nTags, nLabels} {
+		if _, found := attrs[name]; !found {
+			attrs[name] = attrMap{} // empty tags and labels are not migrated
+		}
+	}
+	raw, err := json.Marshal(attrs) // use the same types as in state, e.g. json.Number and []any
+	if err != nil {
+		return nil, err
+	}
+	return decodeAttrs(raw)
+}
+
+func getStateInstanceKey(instance map[string]json.RawMessage) string {
+	raw, found := instance["index_key"]
+	if !found {
+		return ""
+	}
+	return "[" + string(raw) + "]"
+}
+
+// diffConfigExpr compares a configuration expression with its value in state. If the expression can't be evaluated,
+// object and tuple expressions are compared item by item so literal values inside them are still compared.
+func diffConfigExpr(expr hclsyntax.Expression, stateVal any, path string) []string {
+	if val, diags := expr.Value(verifyEvalContext); !diags.HasErrors() && val.IsWhollyKnown() {
+		return diffConfigValue(ctyToAny(val), stateVal, path)
+	}
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		stateObj, _ := stateVal.(map[string]any)
+		var diffs []string
+		for _, item := range e.Items {
+			keyVal, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || !keyVal.IsWhollyKnown() {
+				continue
+			}
+			key, _ := ctyToAny(keyVal).(string)
+			if itemStateVal, found := stateObj[key]; found {
+				diffs = append(diffs, diffConfigExpr(item.ValueExpr, itemStateVal, path+"."+key)...)
+			}
+		}
+		return diffs
+	case *hclsyntax.TupleConsExpr:
+		stateList, _ := stateVal.([]any)
+		if len(stateList) != len(e.Exprs) {
+			return []string{fmt.Sprintf("%s: state has %d elements, config has %d", path, len(stateList), len(e.Exprs))}
+		}
+		var diffs []string
+		for i, itemExpr := range e.Exprs {
+			diffs = append(diffs, diffConfigExpr(itemExpr, stateList[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return diffs
+	}
+	return nil
+}
+
+// diffConfigValue compares an evaluated configuration value with its value in state.
+// Attributes not in state objects are ignored, and attributes in state objects not set in the configuration are
+// ignored as they can be computed, except in tags and labels maps where all keys are compared.
+func diffConfigValue(configVal, stateVal any, path string) []string {
+	switch c := configVal.(type) {
+	case map[string]any:
+		stateObj, _ := stateVal.(map[string]any)
+		isMap := lastPathPart(path) == nTags || lastPathPart(path) == nLabels
+		var diffs []string
+		for key, val := range c {
+			if itemStateVal, found := stateObj[key]; found || isMap {
+				diffs = append(diffs, diffConfigValue(val, itemStateVal, path+"."+key)...)
+			}
+		}
+		for key, val := range stateObj {
+			if _, found := c[key]; isMap && !found {
+				diffs = append(diffs, fmt.Sprintf("%s.%s: state %s, config not set", path, key, formatDriftValue(val)))
+			}
+		}
+		return diffs
+	case []any:
+		stateList, _ := stateVal.([]any)
+		if len(stateList) != len(c) {
+			return []string{fmt.Sprintf("%s: state has %d elements, config has %d", path, len(stateList), len(c))}
+		}
+		var diffs []string
+		for i := range c {
+			diffs = append(diffs, diffConfigValue(c[i], stateList[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return diffs
+	case nil:
+		return nil // null in config means the provider default or computed value is used
+	}
+	if !equalDriftValues(configVal, stateVal) {
+		return []string{fmt.Sprintf("%s: state %s, config %s", path, formatDriftValue(stateVal), formatDriftValue(configVal))}
+	}
+	return nil
+}
+
+func equalDriftValues(configVal, stateVal any) bool {
+	configNum, configIsNum := toBigFloat(configVal)
+	stateNum, stateIsNum := toBigFloat(stateVal)
+	if configIsNum && stateIsNum {
+		return configNum.Cmp(stateNum) == 0
+	}
+	return configVal == stateVal
+}
+
+func toBigFloat(val any) (*big.Float, bool) {
+	switch v := val.(type) {
+	case int:
+		return new(big.Float).SetInt64(int64(v)), true
+	case json.Number:
+		num, ok := new(big.Float).SetString(v.String())
+		return num, ok
+	}
+	return nil, false
+}
+
+func formatDriftValue(val any) string {
+	switch v := val.(type) {
+	case nil:
+		return "not set"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(val)
+}
+
+func lastPathPart(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
@@ -0,0 +1,22 @@
+package convert_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPredictDrift compares a converted configuration (suffix .in.tf) with a state file (suffix .in.tfstate).
+func TestPredictDrift(t *testing.T) {
+	const cmdName = "drift"
+	runConvertTestsWithSuffix(t, cmdName, ".in.tf", ".out.txt", func(testName string, inConfig []byte) ([]byte, error) {
+		state, err := afero.ReadFile(afero.NewOsFs(), filepath.Join("testdata", cmdName, testName+".in.tfstate"))
+		require.NoError(t, err)
+		diffs, err := convert.PredictDrift(inConfig, state, "")
+		return []byte(strings.Join(diffs, "\n") + "\n"), err
+	})
+}
@@ -0,0 +1,471 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	internalhcl "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	// clusterPathRenames are the mongodbatlas_cluster root attributes with a different name in advanced_cluster.
+	clusterPathRenames = map[string]string{
+		nCloudBackup: nBackupEnabled, nProviderBackupEnabledSrc: nBackupEnabled, nBiConnectorSrc: nBiConnector,
+	}
+	// clusterSpecPaths are the mongodbatlas_cluster root attributes set in every spec of the region configs.
+	clusterSpecPaths = map[string]string{
+		nInstanceSizeSrc: nInstanceSize, nDiskSizeGB: nDiskSizeGB, nEBSVolumeTypeSrc: nEBSVolumeType,
+		nDiskIOPSSrc: nDiskIOPS,
+	}
+	// clusterAutoScalingPaths are the mongodbatlas_cluster root attributes set in auto_scaling of the region configs.
+	clusterAutoScalingPaths = map[string]string{
+		nDiskGBEnabledSrc: nDiskGBEnabled, nComputeEnabledSrc: nComputeEnabled,
+		nComputeScaleDownEnabledSrc: nComputeScaleDownEnabled, nComputeMinInstanceSizeSrc: nComputeMinInstanceSize,
+		nComputeMaxInstanceSizeSrc: nComputeMaxInstanceSize,
+	}
+	// clusterRegionPaths are the mongodbatlas_cluster root attributes set in the region configs.
+	clusterRegionPaths = map[string]string{nProviderName: nProviderName, nRegionNameSrc: nRegionName}
+	// clusterRepSpecPaths are the mongodbatlas_cluster replication_specs attributes with an equivalent path.
+	clusterRepSpecPaths = map[string]string{nZoneName: nZoneName, nID: nZoneID}
+)
+
+// pathMapper maps the attribute paths of a cluster before the conversion to the paths after it.
+// A path is a list of attribute names and indexes, e.g. [replication_specs [0] region_configs].
+type pathMapper struct {
+	regionConfigs [][]string // path and specs of every region config, nil if they can't be evaluated statically
+	isCluster     bool       // original resource is mongodbatlas_cluster, otherwise advanced_cluster 1.X.X
+	sameSpecs     bool       // converted replication_specs have the same elements as in the original resource
+}
+
+// newPathMapper returns the path mapper of a converted resource given its original definition.
+func newPathMapper(resource *hclwrite.Block, original []byte) *pathMapper {
+	m := &pathMapper{isCluster: bytesHasResourceType(original, cluster)}
+	expr, err := internalhcl.ParseAttrExpr(resource.Body().GetAttribute(nRepSpecs))
+	specs, ok := expr.(*hclsyntax.TupleConsExpr)
+	if err != nil || !ok {
+		return m
+	}
+	m.regionConfigs = [][]string{}
+	for i, spec := range specs.Exprs {
+		configs, ok := getObjectItemExpr(spec, nConfig).(*hclsyntax.TupleConsExpr)
+		if !ok {
+			m.regionConfigs = nil
+			return m
+		}
+		for j, config := range configs.Exprs {
+			path := []string{nRepSpecs, pathIndex(i), nConfig, pathIndex(j)}
+			for _, name := range specsWithDisk {
+				if getObjectItemExpr(config, name) != nil {
+					path = append(path, name)
+				}
+			}
+			m.regionConfigs = append(m.regionConfigs, path)
+		}
+	}
+	m.sameSpecs = countRepSpecBlocks(original) == len(specs.Exprs)
+	return m
+}
+
+// mapPath returns the paths after the conversion, or false if the path has no equivalent.
+// A path can be mapped to multiple paths, e.g. provider_instance_size_name is in every region config.
+func (m *pathMapper) mapPath(path []string) ([][]string, bool) {
+	name, rest := path[0], path[1:]
+	switch {
+	case !m.isCluster && name == nDiskSizeGB && len(rest) == 0:
+		return m.expandSpecs(nil, nDiskSizeGB)
+	case !m.isCluster:
+		return m.mapNestedPath(path)
+	case clusterRemovedAttrs[name] != "" && name != nReplicationFactorSrc, name == nNumShards,
+		name == nSnapshotBackupPolicy:
+		return nil, false
+	case clusterPathRenames[name] != "":
+		return m.mapNestedPath(append([]string{clusterPathRenames[name]}, rest...))
+	case len(rest) > 0 && (clusterSpecPaths[name] != "" || clusterAutoScalingPaths[name] != "" ||
+		clusterRegionPaths[name] != "" || name == nReplicationFactorSrc):
+		return nil, false
+	case clusterSpecPaths[name] != "":
+		return m.expandSpecs(nil, clusterSpecPaths[name])
+	case name == nReplicationFactorSrc:
+		return m.expandSpecs([]string{nElectableSpecs}, nNodeCount)
+	case clusterAutoScalingPaths[name] != "":
+		return m.expandRegionConfigs(nAutoScaling, clusterAutoScalingPaths[name])
+	case clusterRegionPaths[name] != "":
+		return m.expandRegionConfigs(clusterRegionPaths[name])
+	case name == nRepSpecs && len(rest) > 0:
+		if len(rest) < 2 || !m.isRepSpecIndexMapped(rest[0]) || clusterRepSpecPaths[rest[1]] == "" {
+			return nil, false
+		}
+		return [][]string{{nRepSpecs, rest[0], clusterRepSpecPaths[rest[1]]}}, true
+	}
+	return m.mapNestedPath(path)
+}
+
+// mapNestedPath removes the [0] index after the attributes that are single objects in provider 2.0.0,
+// and maps the replication_specs attributes of advanced_cluster 1.X.X.
+func (m *pathMapper) mapNestedPath(path []string) ([][]string, bool) {
+	var ret []string
+	for i := 0; i < len(path); i++ {
+		ret = append(ret, path[i])
+		switch {
+		case slices.Contains(advClusterObjectAttrs, path[i]) && i+1 < len(path) && path[i+1] == pathIndex(0):
+			i++
+		case path[i] == nRepSpecs && i == 0 && len(path) > 1:
+			if !m.isRepSpecIndexMapped(path[1]) {
+				return nil, false
+			}
+			if len(path) > 2 && path[2] == nNumShards {
+				return nil, false
+			}
+			if len(path) > 2 && path[2] == nID {
+				return [][]string{append(ret, path[1], nZoneID)}, true
+			}
+		}
+	}
+	return [][]string{ret}, true
+}
+
+// expandSpecs returns the path of attrName in every spec of the region configs, only the specs in specNames
+// are included if not nil.
+func (m *pathMapper) expandSpecs(specNames []string, attrName string) ([][]string, bool) {
+	if len(m.regionConfigs) == 0 {
+		return nil, false
+	}
+	var ret [][]string
+	for _, config := range m.regionConfigs {
+		prefix, specs := config[:4], config[4:]
+		for _, spec := range specs {
+			if specNames == nil || slices.Contains(specNames, spec) {
+				ret = append(ret, slices.Concat(prefix, []string{spec, attrName}))
+			}
+		}
+	}
+	return ret, len(ret) > 0
+}
+
+// expandRegionConfigs returns the path of the attribute names in every region config.
+func (m *pathMapper) expandRegionConfigs(names ...string) ([][]string, bool) {
+	if len(m.regionConfigs) == 0 {
+		return nil, false
+	}
+	var ret [][]string
+	for _, config := range m.regionConfigs {
+		ret = append(ret, slices.Concat(config[:4], names))
+	}
+	return ret, true
+}
+
+// isRepSpecIndexMapped returns true if the replication_specs index is the same after the conversion,
+// the first element is always the same but others can change, e.g. if num_shards is greater than 1.
+func (m *pathMapper) isRepSpecIndexMapped(index string) bool {
+	return index == pathIndex(0) || (m.sameSpecs && strings.HasPrefix(index, "["))
+}
+
+// updateLifecycles maps the attribute paths in the lifecycle blocks of all resources and data sources to the
+// converted clusters: ignore_changes and self references in the converted resources, and references to the converted
+// clusters in replace_triggered_by and preconditions and postconditions.
+// mappers has the path mapper of every converted resource of type fromType.
+// Paths without equivalent are removed from ignore_changes, and a warning is added to the lifecycle block.
+func updateLifecycles(body *hclwrite.Body, fromType string, mappers map[*hclwrite.Block]*pathMapper) {
+	labels := make(map[string]*pathMapper)
+	for block, mapper := range mappers {
+		if label := getResourceLabel(block); label != "" {
+			labels[label] = mapper
+		}
+	}
+	for _, block := range body.Blocks() {
+		if block.Type() != resourceType && block.Type() != dataSourceType {
+			continue
+		}
+		selfMapper := mappers[block]
+		getMapper := func(traversal hcl.Traversal) (*pathMapper, int) {
+			if traversal.RootName() == nSelf {
+				return selfMapper, 1
+			}
+			if traversal.RootName() != fromType || len(traversal) < 2 {
+				return nil, 0
+			}
+			attr, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				return nil, 0
+			}
+			pathStart := 2
+			if len(traversal) > 2 {
+				if _, isIndex := traversal[2].(hcl.TraverseIndex); isIndex {
+					pathStart = 3
+				}
+			}
+			return labels[attr.Name], pathStart
+		}
+		for _, lifecycle := range block.Body().Blocks() {
+			if lifecycle.Type() != nLifecycle {
+				continue
+			}
+			var warnings []string
+			lifecycleb := lifecycle.Body()
+			if selfMapper != nil {
+				warnings = append(warnings, updateIgnoreChanges(lifecycleb, selfMapper)...)
+			}
+			warnings = append(warnings, updateReplaceTriggeredBy(lifecycleb, fromType, getMapper)...)
+			for _, condition := range lifecycleb.Blocks() {
+				if condition.Type() != nPrecondition && condition.Type() != nPostcondition {
+					continue
+				}
+				for _, name := range []string{nCondition, nErrorMessage} {
+					warnings = append(warnings, updateConditionExpr(condition.Body(), name, getMapper)...)
+				}
+			}
+			for _, warning := range warnings {
+				internalhcl.AppendComment(lifecycleb, warning)
+			}
+		}
+	}
+}
+
+// updateIgnoreChanges maps the relative paths in ignore_changes, paths without equivalent are removed.
+func updateIgnoreChanges(lifecycleb *hclwrite.Body, mapper *pathMapper) []string {
+	exprs, tuple := getTupleExprs(lifecycleb.GetAttribute(nIgnoreChanges))
+	if tuple == nil {
+		return nil
+	}
+	var (
+		items    []string
+		warnings []string
+		updated  bool
+	)
+	for _, expr := range exprs {
+		traversal, diags := hcl.RelTraversalForExpr(expr)
+		path, ok := traversalToPath(traversal)
+		if diags.HasErrors() || !ok {
+			items = append(items, getExprText(tuple.bytes, expr))
+			continue
+		}
+		paths, ok := mapper.mapPath(path)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf(commentLifecycleRemoved, renderPath(path), nIgnoreChanges))
+			updated = true
+			continue
+		}
+		for _, newPath := range paths {
+			items = append(items, renderPath(newPath))
+		}
+		updated = updated || len(paths) != 1 || !slices.Equal(paths[0], path)
+	}
+	if updated {
+		multiline := tuple.multiline || len(items) > len(exprs)
+		lifecycleb.SetAttributeRaw(nIgnoreChanges, internalhcl.TokensArrayExprs(items, multiline))
+	}
+	return warnings
+}
+
+// updateReplaceTriggeredBy maps the references to the converted clusters in replace_triggered_by.
+func updateReplaceTriggeredBy(lifecycleb *hclwrite.Body, fromType string,
+	getMapper func(hcl.Traversal) (*pathMapper, int)) []string {
+	exprs, tuple := getTupleExprs(lifecycleb.GetAttribute(nReplaceTriggeredBy))
+	if tuple == nil {
+		return nil
+	}
+	var (
+		items    []string
+		warnings []string
+		updated  bool
+	)
+	for _, expr := range exprs {
+		text := getExprText(tuple.bytes, expr)
+		traversal, diags := hcl.AbsTraversalForExpr(expr)
+		if diags.HasErrors() {
+			items = append(items, text)
+			continue
+		}
+		refs, ok := mapReference(traversal, fromType, getMapper)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf(commentLifecycleReview, text, nReplaceTriggeredBy))
+		}
+		if len(refs) == 0 {
+			items = append(items, text)
+			continue
+		}
+		items = append(items, refs...)
+		updated = updated || len(refs) != 1 || refs[0] != text
+	}
+	if updated {
+		multiline := tuple.multiline || len(items) > len(exprs)
+		lifecycleb.SetAttributeRaw(nReplaceTriggeredBy, internalhcl.TokensArrayExprs(items, multiline))
+	}
+	return warnings
+}
+
+// updateConditionExpr maps the references in a precondition or postcondition attribute,
+// they're only changed if they have exactly one equivalent path.
+func updateConditionExpr(body *hclwrite.Body, name string, getMapper func(hcl.Traversal) (*pathMapper, int)) []string {
+	attr := body.GetAttribute(name)
+	if attr == nil {
+		return nil
+	}
+	src := attr.Expr().BuildTokens(nil).Bytes()
+	expr, diags := hclsyntax.ParseExpression(src, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+	var warnings []string
+	traversals := expr.Variables()
+	updated := slices.Clone(src)
+	for i := len(traversals) - 1; i >= 0; i-- { // replace from the end so previous ranges are still valid
+		traversal := traversals[i]
+		rng := traversal.SourceRange()
+		text := string(src[rng.Start.Byte:rng.End.Byte])
+		refs, ok := mapReference(traversal, "", getMapper)
+		if !ok || len(refs) > 1 {
+			warnings = append(warnings, fmt.Sprintf(commentLifecycleReview, text, name))
+			continue
+		}
+		if len(refs) == 1 && refs[0] != text {
+			updated = slices.Concat(updated[:rng.Start.Byte], []byte(refs[0]), updated[rng.End.Byte:])
+		}
+	}
+	if !slices.Equal(updated, src) {
+		body.SetAttributeRaw(name, internalhcl.TokensFromExpr(strings.TrimSpace(string(updated))))
+	}
+	slices.Reverse(warnings)
+	return warnings
+}
+
+// mapReference returns the references after the conversion, or false if the reference has no equivalent.
+// No references are returned if the reference is not to a converted cluster. fromType is used as the type of
+// the converted references if not empty, otherwise mongodbatlas_advanced_cluster.
+func mapReference(traversal hcl.Traversal, fromType string,
+	getMapper func(hcl.Traversal) (*pathMapper, int)) ([]string, bool) {
+	mapper, pathStart := getMapper(traversal)
+	if mapper == nil {
+		return nil, true
+	}
+	prefix, _ := traversalToPath(traversal[:pathStart])
+	if prefix[0] == fromType || prefix[0] == cluster {
+		prefix[0] = advCluster
+	}
+	path, ok := traversalToPath(traversal[pathStart:])
+	if !ok {
+		return nil, false
+	}
+	if len(path) == 0 {
+		return []string{renderPath(prefix)}, true
+	}
+	paths, ok := mapper.mapPath(path)
+	if !ok {
+		return nil, false
+	}
+	var ret []string
+	for _, newPath := range paths {
+		ret = append(ret, renderPath(slices.Concat(prefix, newPath)))
+	}
+	return ret, true
+}
+
+// tupleSource is the source of a tuple expression, multiline is true if elements are in different lines.
+type tupleSource struct {
+	bytes     []byte
+	multiline bool
+}
+
+// getTupleExprs returns the elements of an attribute with a tuple expression, e.g. [a, b],
+// or nil if the attribute is not set or is not a tuple.
+func getTupleExprs(attr *hclwrite.Attribute) ([]hclsyntax.Expression, *tupleSource) {
+	if attr == nil {
+		return nil, nil
+	}
+	src := attr.Expr().BuildTokens(nil).Bytes()
+	expr, diags := hclsyntax.ParseExpression(src, "", hcl.InitialPos)
+	tuple, ok := expr.(*hclsyntax.TupleConsExpr)
+	if diags.HasErrors() || !ok {
+		return nil, nil
+	}
+	return tuple.Exprs, &tupleSource{bytes: src, multiline: strings.Contains(strings.TrimSpace(string(src)), "\n")}
+}
+
+func getExprText(src []byte, expr hclsyntax.Expression) string {
+	rng := expr.Range()
+	return string(src[rng.Start.Byte:rng.End.Byte])
+}
+
+// traversalToPath returns the attribute names and literal indexes of a traversal, e.g. [replication_specs [0]].
+func traversalToPath(traversal hcl.Traversal) ([]string, bool) {
+	var path []string
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			path = append(path, s.Name)
+		case hcl.TraverseAttr:
+			path = append(path, s.Name)
+		case hcl.TraverseIndex:
+			switch {
+			case s.Key.Type() == cty.Number && s.Key.IsKnown() && !s.Key.IsNull():
+				path = append(path, "["+s.Key.AsBigFloat().Text('f', -1)+"]")
+			case s.Key.Type() == cty.String && s.Key.IsKnown() && !s.Key.IsNull():
+				path = append(path, "["+strconv.Quote(s.Key.AsString())+"]")
+			default:
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return path, true
+}
+
+func renderPath(path []string) string {
+	var sb strings.Builder
+	for i, part := range path {
+		if i > 0 && !strings.HasPrefix(part, "[") {
+			sb.WriteString(".")
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+func pathIndex(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+// getObjectItemExpr returns the expression of an attribute in an object expression, or nil if not found.
+func getObjectItemExpr(expr hclsyntax.Expression, name string) hclsyntax.Expression {
+	obj, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return nil
+	}
+	for _, item := range obj.Items {
+		if hcl.ExprAsKeyword(item.KeyExpr) == name {
+			return item.ValueExpr
+		}
+	}
+	return nil
+}
+
+// countRepSpecBlocks returns the number of replication_specs blocks in a resource definition,
+// or -1 if it can't be counted, e.g. because of dynamic blocks.
+func countRepSpecBlocks(original []byte) int {
+	blocks, err := getResourceBodies(original)
+	if err != nil || len(blocks) != 1 {
+		return -1
+	}
+	count := 0
+	for _, block := range blocks[0].Body.Blocks {
+		if block.Type == nDynamic {
+			return -1
+		}
+		if block.Type == nRepSpecs {
+			count++
+		}
+	}
+	return count
+}
+
+func bytesHasResourceType(original []byte, resType string) bool {
+	blocks, err := getResourceBodies(original)
+	return err == nil && len(blocks) == 1 && blocks[0].Labels[0] == resType
+}
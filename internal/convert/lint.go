@@ -0,0 +1,276 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// validElectableNodes are the allowed totals of electable nodes in a shard.
+var validElectableNodes = []int{3, 5, 7}
+
+// LintIssue is a topology rule broken by a mongodbatlas_advanced_cluster resource.
+type LintIssue struct {
+	Address string // e.g. mongodbatlas_advanced_cluster.cluster
+	Message string
+	Range   hcl.Range // region config or shard of the issue, or definition of the resource if they're not found
+}
+
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", i.Range.Filename, i.Range.Start.Line, i.Address, i.Message)
+}
+
+// LintReport is the result of checking the topology of the clusters in a configuration.
+type LintReport struct {
+	Issues    []LintIssue
+	NotLinted []string // clusters that can't be evaluated statically, e.g. because they use variables
+}
+
+// topologyIssue is a topology rule broken in a shard, or in one of its region configs if config is not negative.
+type topologyIssue struct {
+	message string
+	spec    int
+	config  int
+}
+
+// syntaxItem is a nested block in provider 1.X.X schema, or an object in a list nested attribute in 2.0.0.
+type syntaxItem struct {
+	body   *hclsyntax.Body
+	object *hclsyntax.ObjectConsExpr
+	rng    hcl.Range
+}
+
+// LintTopology checks the mongodbatlas_advanced_cluster resources in a configuration, converted or hand-written in
+// provider 1.X.X or 2.0.0 schema, against the Atlas topology rules:
+//   - electable nodes in each shard are 3, 5 or 7.
+//   - priorities of the regions with electable nodes in each shard start at 7 and are strictly descending.
+//   - a region is not repeated in a shard.
+//   - region names are Atlas regions of their provider, or backing provider in TENANT region configs.
+//   - auto scaling min instance size <= instance size <= max instance size.
+//   - auto scaling is the same in all region configs.
+//   - analytics auto scaling is only set in region configs with analytics nodes.
+//
+// Only literal values and simple functions can be evaluated, other clusters are reported as not linted.
+// filename is only used in the issue positions.
+func LintTopology(config []byte, filename string) (LintReport, error) {
+	var report LintReport
+	resources, err := getResourceBodies(config)
+	if err != nil {
+		return report, err
+	}
+	for _, block := range resources {
+		if block.Labels[0] != advCluster {
+			continue
+		}
+		address := getSyntaxBlockAddress(block)
+		attrs, err := evalBody(block.Body, advClusterTopologyAttrs)
+		if err != nil {
+			report.NotLinted = append(report.NotLinted, fmt.Sprintf("%s: %s", address, err))
+			continue
+		}
+		for _, issue := range lintCluster(attrs) {
+			rng := getTopologyRange(block, issue)
+			rng.Filename = filename
+			report.Issues = append(report.Issues, LintIssue{Address: address, Message: issue.message, Range: rng})
+		}
+	}
+	return report, nil
+}
+
+// lintCluster returns the topology rules broken by the attributes of a cluster.
+func lintCluster(attrs attrMap) []topologyIssue {
+	var issues []topologyIssue
+	var firstAutoScaling, firstAnalyticsAutoScaling string // first region config to compare the others with
+	for i, spec := range getList(attrs, nRepSpecs) {
+		configs := getList(spec, nConfig)
+		for j, config := range configs {
+			if issue := getRegionIssue(getRegionProviderName(config), getString(config[nRegionName])); issue != "" {
+				issues = append(issues, topologyIssue{spec: i, config: j, message: fmt.Sprintf("%s[%d].%s[%d]: %s",
+					nRepSpecs, i, nConfig, j, strings.TrimSuffix(issue, "."))})
+			}
+		}
+		if slices.ContainsFunc(configs, func(config attrMap) bool { return getString(config[nProviderName]) == nTenant }) {
+			continue // tenant clusters have a fixed topology
+		}
+		issues = append(issues, lintShard(i, configs)...)
+		for j, config := range configs {
+			prefix := fmt.Sprintf("%s[%d].%s[%d]", nRepSpecs, i, nConfig, j)
+			messages := slices.Concat(lintAutoScaling(prefix, config, nElectableSpecs, nAutoScaling),
+				lintAutoScaling(prefix, config, nAnalyticsSpecs, nAnalyticsAutoScaling))
+			autoScaling := fmt.Sprint(getObject(config, nAutoScaling))
+			if firstAutoScaling == "" {
+				firstAutoScaling = autoScaling
+			} else if autoScaling != firstAutoScaling {
+				messages = append(messages, fmt.Sprintf("%s: %s must be the same in all region configs",
+					prefix, nAutoScaling))
+			}
+			analyticsAutoScaling := getObject(config, nAnalyticsAutoScaling)
+			switch {
+			case getInt(getObject(config, nAnalyticsSpecs)[nNodeCount]) == 0:
+				if isAutoScalingEnabled(analyticsAutoScaling) {
+					messages = append(messages, fmt.Sprintf("%s: %s is set but there are no analytics nodes",
+						prefix, nAnalyticsAutoScaling))
+				}
+			case firstAnalyticsAutoScaling == "":
+				firstAnalyticsAutoScaling = fmt.Sprint(analyticsAutoScaling)
+			case fmt.Sprint(analyticsAutoScaling) != firstAnalyticsAutoScaling:
+				messages = append(messages, fmt.Sprintf("%s: %s must be the same in all region configs with analytics nodes",
+					prefix, nAnalyticsAutoScaling))
+			}
+			for _, msg := range messages {
+				issues = append(issues, topologyIssue{spec: i, config: j, message: msg})
+			}
+		}
+	}
+	return issues
+}
+
+// lintShard checks the electable nodes, priorities and regions of the region configs in a shard.
+func lintShard(index int, configs []attrMap) []topologyIssue {
+	var issues []topologyIssue
+	prefix := fmt.Sprintf("%s[%d]", nRepSpecs, index)
+	electable := 0
+	var regions []string
+	var priorities []int
+	for j, config := range configs {
+		region := getString(config[nRegionName])
+		key := getString(config[nProviderName]) + "/" + region // same region names are used by different providers
+		if slices.Contains(regions, key) {
+			issues = append(issues, topologyIssue{spec: index, config: j, message: fmt.Sprintf(
+				"%s.%s[%d]: region %s is repeated in the shard", prefix, nConfig, j, region)})
+		}
+		regions = append(regions, key)
+		nodes := getInt(getObject(config, nElectableSpecs)[nNodeCount])
+		if nodes == 0 {
+			continue
+		}
+		electable += nodes
+		priority := getInt(config[nPriority])
+		switch {
+		case slices.Contains(priorities, priority):
+			issues = append(issues, topologyIssue{spec: index, config: j, message: fmt.Sprintf(
+				"%s.%s[%d]: %s %d is repeated in the shard", prefix, nConfig, j, nPriority, priority)})
+		case len(priorities) == 0 && priority != valMaxPriority:
+			issues = append(issues, topologyIssue{spec: index, config: j, message: fmt.Sprintf(
+				"%s.%s[%d]: %s must start at %d, found %d", prefix, nConfig, j, nPriority, valMaxPriority, priority)})
+		case len(priorities) > 0 && priority > priorities[len(priorities)-1]:
+			issues = append(issues, topologyIssue{spec: index, config: j, message: fmt.Sprintf(
+				"%s.%s[%d]: %s must be in descending order, found %d after %d",
+				prefix, nConfig, j, nPriority, priority, priorities[len(priorities)-1])})
+		}
+		priorities = append(priorities, priority)
+	}
+	if !slices.Contains(validElectableNodes, electable) {
+		issues = append(issues, topologyIssue{spec: index, config: -1, message: fmt.Sprintf(
+			"%s: electable nodes must be 3, 5 or 7 in the shard, found %d", prefix, electable)})
+	}
+	return issues
+}
+
+// getTopologyRange returns the range of the region config of an issue, or of its shard if config is negative.
+// The definition of the resource is returned if they can't be found, e.g. in dynamic blocks or list functions.
+func getTopologyRange(block *hclsyntax.Block, issue topologyIssue) hcl.Range {
+	root := syntaxItem{body: block.Body, rng: block.DefRange()}
+	spec, found := getSyntaxItem(root, nRepSpecs, issue.spec)
+	if !found {
+		return root.rng
+	}
+	if issue.config < 0 {
+		return spec.rng
+	}
+	if config, found := getSyntaxItem(spec, nConfig, issue.config); found {
+		return config.rng
+	}
+	return spec.rng
+}
+
+// getSyntaxItem returns the n-th (starting from 0) nested block or object of the list nested attribute name.
+func getSyntaxItem(parent syntaxItem, name string, n int) (syntaxItem, bool) {
+	var expr hclsyntax.Expression
+	if parent.body != nil {
+		var blocks []*hclsyntax.Block
+		for _, block := range parent.body.Blocks {
+			if block.Type == name {
+				blocks = append(blocks, block)
+			}
+		}
+		if n < len(blocks) {
+			return syntaxItem{body: blocks[n].Body, rng: blocks[n].DefRange()}, true
+		}
+		if attr := parent.body.Attributes[name]; attr != nil {
+			expr = attr.Expr
+		}
+	}
+	if parent.object != nil {
+		for _, item := range parent.object.Items {
+			if hcl.ExprAsKeyword(item.KeyExpr) == name {
+				expr = item.ValueExpr
+			}
+		}
+	}
+	if tuple, ok := expr.(*hclsyntax.TupleConsExpr); ok && n < len(tuple.Exprs) {
+		if object, ok := tuple.Exprs[n].(*hclsyntax.ObjectConsExpr); ok {
+			return syntaxItem{object: object, rng: object.Range()}, true
+		}
+	}
+	return syntaxItem{}, false
+}
+
+// lintAutoScaling checks that the instance size of specName is between the compute min and max instance sizes
+// of autoScalingName, if compute auto scaling is enabled.
+func lintAutoScaling(prefix string, config attrMap, specName, autoScalingName string) []string {
+	autoScaling := getObject(config, autoScalingName)
+	if enabled, _ := autoScaling[nComputeEnabled].(bool); !enabled {
+		return nil
+	}
+	size := getString(getObject(config, specName)[nInstanceSize])
+	minSize := getString(autoScaling[nComputeMinInstanceSize])
+	maxSize := getString(autoScaling[nComputeMaxInstanceSize])
+	var issues []string
+	if minSize != "" && compareInstanceSizes(minSize, maxSize) > 0 {
+		issues = append(issues, fmt.Sprintf("%s: %s %s %s is greater than %s %s",
+			prefix, autoScalingName, nComputeMinInstanceSize, minSize, nComputeMaxInstanceSize, maxSize))
+	}
+	if size != "" && minSize != "" && compareInstanceSizes(size, minSize) < 0 {
+		issues = append(issues, fmt.Sprintf("%s: %s %s %s is less than %s %s %s",
+			prefix, specName, nInstanceSize, size, autoScalingName, nComputeMinInstanceSize, minSize))
+	}
+	if size != "" && maxSize != "" && compareInstanceSizes(size, maxSize) > 0 {
+		issues = append(issues, fmt.Sprintf("%s: %s %s %s is greater than %s %s %s",
+			prefix, specName, nInstanceSize, size, autoScalingName, nComputeMaxInstanceSize, maxSize))
+	}
+	return issues
+}
+
+func isAutoScalingEnabled(autoScaling attrMap) bool {
+	for _, name := range []string{nComputeEnabled, nDiskGBEnabled} {
+		if enabled, _ := autoScaling[name].(bool); enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// compareInstanceSizes compares the tier number of two instance sizes, e.g. M10 < M30 and R40 = M40_NVME.
+// It returns 0 if any of them doesn't have a tier number.
+func compareInstanceSizes(a, b string) int {
+	tierA, okA := getInstanceTier(a)
+	tierB, okB := getInstanceTier(b)
+	if !okA || !okB {
+		return 0
+	}
+	return tierA - tierB
+}
+
+func getInstanceTier(size string) (int, bool) {
+	digits := strings.TrimLeft(size, "MR")
+	if end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		digits = digits[:end]
+	}
+	tier, err := strconv.Atoi(digits)
+	return tier, err == nil && len(digits) < len(size)
+}
@@ -0,0 +1,23 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+)
+
+// TestLintTopology checks the topology issues found in the clusters of a configuration (suffix .in.tf).
+func TestLintTopology(t *testing.T) {
+	runConvertTestsWithSuffix(t, "lint", ".in.tf", ".out.txt", func(testName string, inConfig []byte) ([]byte, error) {
+		report, err := convert.LintTopology(inConfig, testName+".in.tf")
+		var sb strings.Builder
+		for i := range report.Issues {
+			sb.WriteString("issue: " + report.Issues[i].String() + "\n")
+		}
+		for _, notLinted := range report.NotLinted {
+			sb.WriteString("not linted: " + notLinted + "\n")
+		}
+		return []byte(sb.String()), err
+	})
+}
@@ -0,0 +1,344 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+const (
+	stateVersion          = 4
+	stateModeManaged      = "managed"
+	advClusterSchemaV2    = 2 // schema version of mongodbatlas_advanced_cluster in provider 2.0.0
+	errStateMigration     = "migrating state"
+	nStateAttributes      = "attributes"
+	nStateSchemaVersion   = "schema_version"
+	nStatePrivate         = "private"
+	nStateSensitiveAttrs  = "sensitive_attributes"
+	nZoneID               = "zone_id"
+	nExternalID           = "external_id"
+	nContainerID          = "container_id"
+	nReplicationFactorSrc = "replication_factor"
+)
+
+var (
+	// rootAttrsV2 are the root attributes copied unchanged to mongodbatlas_advanced_cluster in provider 2.0.0.
+	// Other computed attributes are not copied as they're populated by the provider in the next refresh.
+	rootAttrsV2 = []string{
+		nProjectID, nName, "cluster_id", nClusterType, nBackupEnabled, "encryption_at_rest_provider",
+		"global_cluster_self_managed_sharding", "mongo_db_major_version", "mongo_db_version", "paused", "pit_enabled",
+		"redact_client_log_data", "replica_set_scaling_strategy", "root_cert_type", "state_name",
+		"termination_protection_enabled", "version_release_system", "config_server_management_mode",
+		"config_server_type", "create_date",
+	}
+	specAttrsV2        = []string{nInstanceSize, nNodeCount, nDiskSizeGB, nDiskIOPS, nEBSVolumeType}
+	autoScalingAttrsV2 = []string{
+		nDiskGBEnabled, nComputeEnabled, nComputeScaleDownEnabled, nComputeMinInstanceSize, nComputeMaxInstanceSize,
+	}
+	advConfigDeprecatedAttrs = []string{nFailIndexKeyTooLong, nDefaultReadConcern}
+)
+
+type tfState struct {
+	Outputs          json.RawMessage `json:"outputs,omitempty"`
+	CheckResults     json.RawMessage `json:"check_results"`
+	TerraformVersion string          `json:"terraform_version"`
+	Lineage          string          `json:"lineage"`
+	Resources        []tfResource    `json:"resources"`
+	Version          int             `json:"version"`
+	Serial           int             `json:"serial"`
+}
+
+type tfResource struct {
+	Each      string                       `json:"each,omitempty"`
+	Module    string                       `json:"module,omitempty"`
+	Mode      string                       `json:"mode"`
+	Type      string                       `json:"type"`
+	Name      string                       `json:"name"`
+	Provider  string                       `json:"provider"`
+	Instances []map[string]json.RawMessage `json:"instances"`
+}
+
+type attrMap = map[string]any
+
+// MigrateState transforms all mongodbatlas_cluster and provider 1.X.X mongodbatlas_advanced_cluster instances
+// in a Terraform state file (format version 4) into mongodbatlas_advanced_cluster provider 2.0.0 schema.
+// The serial is incremented so the result can be uploaded with terraform state push.
+// All other resources are left untouched.
+func MigrateState(stateContent []byte) ([]byte, error) {
+	var state tfState
+	if err := json.Unmarshal(stateContent, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse Terraform state file: %w", err)
+	}
+	if state.Version != stateVersion {
+		return nil, fmt.Errorf("%s: state format version %d is not supported, only version %d",
+			errStateMigration, state.Version, stateVersion)
+	}
+	existing := make(map[string]bool) // managed resources, data sources can have the same address
+	for i := range state.Resources {
+		if state.Resources[i].Mode == stateModeManaged {
+			existing[getStateAddress(&state.Resources[i], state.Resources[i].Type)] = true
+		}
+	}
+	for i := range state.Resources {
+		res := &state.Resources[i]
+		if res.Mode != stateModeManaged || (res.Type != cluster && res.Type != advCluster) {
+			continue
+		}
+		if res.Type == cluster && existing[getStateAddress(res, advCluster)] {
+			return nil, fmt.Errorf("%s: %s already exists", errStateMigration, getStateAddress(res, advCluster))
+		}
+		if err := migrateResourceInstances(res); err != nil {
+			return nil, err
+		}
+	}
+	state.Serial++
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func migrateResourceInstances(res *tfResource) error {
+	for _, instance := range res.Instances {
+		address := getStateAddress(res, res.Type)
+		var version int
+		if err := json.Unmarshal(instance[nStateSchemaVersion], &version); err != nil {
+			return fmt.Errorf("%s %s: invalid %s", errStateMigration, address, nStateSchemaVersion)
+		}
+		if res.Type == advCluster && version >= advClusterSchemaV2 {
+			continue // already in provider 2.0.0 schema
+		}
+		attrs, err := decodeAttrs(instance[nStateAttributes])
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", errStateMigration, address, err)
+		}
+		var newAttrs attrMap
+		if res.Type == cluster {
+			newAttrs = migrateClusterAttrs(attrs)
+		} else {
+			newAttrs = migrateAdvClusterAttrs(attrs)
+		}
+		instance[nStateAttributes], _ = json.Marshal(newAttrs)
+		instance[nStateSchemaVersion], _ = json.Marshal(advClusterSchemaV2)
+		instance[nStateSensitiveAttrs] = json.RawMessage("[]")
+		delete(instance, nStatePrivate) // private data of SDKv2 resources is not valid in TPF resources
+	}
+	res.Type = advCluster
+	return nil
+}
+
+// migrateClusterAttrs returns the attributes of a mongodbatlas_cluster instance in advanced_cluster 2.0.0 schema.
+func migrateClusterAttrs(src attrMap) attrMap {
+	dst := copyRootAttrs(src)
+	setIfNotEmpty(dst, nBackupEnabled, nCloudBackup, src)
+	spec := attrMap{}
+	for srcName, dstName := range map[string]string{nInstanceSizeSrc: nInstanceSize, nDiskSizeGB: nDiskSizeGB,
+		nDiskIOPSSrc: nDiskIOPS, nEBSVolumeTypeSrc: nEBSVolumeType} {
+		setIfNotEmpty(spec, dstName, srcName, src)
+	}
+	autoScaling := attrMap{}
+	for srcName, dstName := range map[string]string{nDiskGBEnabledSrc: nDiskGBEnabled,
+		nComputeEnabledSrc: nComputeEnabled, nComputeScaleDownEnabledSrc: nComputeScaleDownEnabled,
+		nComputeMinInstanceSizeSrc: nComputeMinInstanceSize, nComputeMaxInstanceSizeSrc: nComputeMaxInstanceSize} {
+		setIfNotEmpty(autoScaling, dstName, srcName, src)
+	}
+	var repSpecs []any
+	for _, specSrc := range getList(src, nRepSpecs) {
+		var configs []attrMap
+		for _, configSrc := range getList(specSrc, nConfigSrc) {
+			config := newClusterConfigAttrs(src, configSrc[nRegionName], configSrc[nPriority])
+			setClusterSpec(config, nElectableSpecs, configSrc[nElectableNodes], spec)
+			setClusterSpec(config, nReadOnlySpecs, configSrc[nReadOnlyNodes], spec)
+			setClusterSpec(config, nAnalyticsSpecs, configSrc[nAnalyticsNodes], spec)
+			if len(autoScaling) > 0 {
+				config[nAutoScaling] = autoScaling
+			}
+			configs = append(configs, config)
+		}
+		sortConfigsAttrsByPriority(configs)
+		repSpec := attrMap{nZoneName: specSrc[nZoneName], nConfig: configs}
+		for range max(getInt(specSrc[nNumShards]), 1) {
+			repSpecs = append(repSpecs, repSpec)
+		}
+	}
+	if len(repSpecs) == 0 { // free tier or clusters without replication_specs
+		config := newClusterConfigAttrs(src, src[nRegionNameSrc], valMaxPriority)
+		nodes := valDefaultNodeCount
+		if replicationFactor := getInt(src[nReplicationFactorSrc]); replicationFactor > 0 {
+			nodes = replicationFactor
+		}
+		setClusterSpec(config, nElectableSpecs, nodes, spec)
+		repSpecs = append(repSpecs, attrMap{nConfig: []attrMap{config}})
+	}
+	dst[nRepSpecs] = repSpecs
+	setCommonObjectAttrs(dst, src)
+	return dst
+}
+
+// migrateAdvClusterAttrs returns the attributes of a provider 1.X.X mongodbatlas_advanced_cluster instance
+// in advanced_cluster 2.0.0 schema.
+func migrateAdvClusterAttrs(src attrMap) attrMap {
+	dst := copyRootAttrs(src)
+	var repSpecs []any
+	for _, specSrc := range getList(src, nRepSpecs) {
+		var configs []attrMap
+		for _, configSrc := range getList(specSrc, nConfig) {
+			config := attrMap{}
+			for _, name := range []string{nProviderName, nBackingProviderName, nRegionName, nPriority} {
+				setIfNotEmpty(config, name, name, configSrc)
+			}
+			for _, name := range specsWithDisk {
+				if specs := getList(configSrc, name); len(specs) > 0 && getInt(specs[0][nNodeCount]) > 0 {
+					config[name] = filterAttrs(specs[0], specAttrsV2)
+				}
+			}
+			for _, name := range specsWithoutDisk {
+				if specs := getList(configSrc, name); len(specs) > 0 {
+					config[name] = filterAttrs(specs[0], autoScalingAttrsV2)
+				}
+			}
+			configs = append(configs, config)
+		}
+		repSpec := attrMap{nZoneName: specSrc[nZoneName], nConfig: configs}
+		for _, name := range []string{nZoneID, nExternalID, nContainerID} {
+			setIfNotEmpty(repSpec, name, name, specSrc)
+		}
+		for range max(getInt(specSrc[nNumShards]), 1) {
+			repSpecs = append(repSpecs, repSpec)
+		}
+	}
+	dst[nRepSpecs] = repSpecs
+	setCommonObjectAttrs(dst, src)
+	if pinned := getList(src, nPinnedFCV); len(pinned) > 0 {
+		dst[nPinnedFCV] = pinned[0]
+	}
+	return dst
+}
+
+func newClusterConfigAttrs(src attrMap, regionName, priority any) attrMap {
+	config := attrMap{nProviderName: src[nProviderName], nRegionName: regionName, nPriority: priority}
+	if src[nProviderName] == nTenant {
+		config[nBackingProviderName] = src[nBackingProviderName]
+	}
+	return config
+}
+
+func copyRootAttrs(src attrMap) attrMap {
+	dst := attrMap{}
+	for _, name := range rootAttrsV2 {
+		if val, found := src[name]; found {
+			dst[name] = val
+		}
+	}
+	return dst
+}
+
+// setCommonObjectAttrs sets the attributes that changed from blocks to maps or objects.
+func setCommonObjectAttrs(dst, src attrMap) {
+	for _, name := range []string{nTags, nLabels} {
+		if list := getList(src, name); len(list) > 0 {
+			m := attrMap{}
+			for _, item := range list {
+				if key, ok := item[nKey].(string); ok {
+					m[key] = item[nValue]
+				}
+			}
+			dst[name] = m
+		}
+	}
+	if advConfig := getList(src, nAdvConfig); len(advConfig) > 0 {
+		obj := attrMap{}
+		for name, val := range advConfig[0] {
+			if !slices.Contains(advConfigDeprecatedAttrs, name) {
+				obj[name] = val
+			}
+		}
+		dst[nAdvConfig] = obj
+	}
+	if biConnector := getList(src, nBiConnector); len(biConnector) > 0 {
+		dst[nBiConnector] = biConnector[0]
+	}
+}
+
+func setClusterSpec(config attrMap, specName string, nodes any, spec attrMap) {
+	count := getInt(nodes)
+	if count == 0 {
+		return
+	}
+	obj := attrMap{nNodeCount: count}
+	for name, val := range spec {
+		obj[name] = val
+	}
+	config[specName] = obj
+}
+
+func sortConfigsAttrsByPriority(configs []attrMap) {
+	sort.SliceStable(configs, func(i, j int) bool {
+		return getInt(configs[i][nPriority]) > getInt(configs[j][nPriority])
+	})
+}
+
+func filterAttrs(src attrMap, names []string) attrMap {
+	dst := attrMap{}
+	for _, name := range names {
+		setIfNotEmpty(dst, name, name, src)
+	}
+	return dst
+}
+
+func setIfNotEmpty(dst attrMap, dstName, srcName string, src attrMap) {
+	if val, found := src[srcName]; found && val != nil && val != "" {
+		dst[dstName] = val
+	}
+}
+
+// getList returns a list of objects attribute, e.g. a block in SDKv2 resources.
+func getList(src attrMap, name string) []attrMap {
+	if list, ok := src[name].([]attrMap); ok {
+		return list
+	}
+	items, _ := src[name].([]any)
+	var ret []attrMap
+	for _, item := range items {
+		if obj, ok := item.(map[string]any); ok {
+			ret = append(ret, obj)
+		}
+	}
+	return ret
+}
+
+func getInt(val any) int {
+	switch v := val.(type) {
+	case json.Number:
+		num, _ := v.Int64()
+		return int(num)
+	case int:
+		return v
+	}
+	return 0
+}
+
+func decodeAttrs(raw json.RawMessage) (attrMap, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber() // keep numbers as they are
+	var attrs attrMap
+	if err := decoder.Decode(&attrs); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", nStateAttributes, err)
+	}
+	return attrs, nil
+}
+
+// getStateAddress returns the address of a resource in the state, e.g. module.db.mongodbatlas_cluster.this.
+func getStateAddress(res *tfResource, resType string) string {
+	address := fmt.Sprintf("%s.%s", resType, res.Name)
+	if res.Module != "" {
+		address = res.Module + "." + address
+	}
+	return address
+}
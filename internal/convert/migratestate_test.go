@@ -0,0 +1,52 @@
+package convert_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/schema"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateState(t *testing.T) {
+	runConvertTestsWithSuffix(t, "migratestate", ".in.tfstate", ".out.tfstate",
+		func(testName string, inConfig []byte) ([]byte, error) {
+			return convert.MigrateState(inConfig)
+		})
+}
+
+// TestMigrateStateSchemaVersion checks that the schema_version of the migrated advanced clusters is the one
+// of mongodbatlas_advanced_cluster in the provider schema snapshot.
+func TestMigrateStateSchemaVersion(t *testing.T) {
+	const advCluster = "mongodbatlas_advanced_cluster"
+	expected, found := schema.ResourceVersion(advCluster)
+	require.True(t, found, "%s not found in the provider schema snapshot", advCluster)
+	content, err := afero.ReadFile(afero.NewOsFs(), filepath.Join("testdata", "migratestate", "basic.in.tfstate"))
+	require.NoError(t, err)
+	migrated, err := convert.MigrateState(content)
+	require.NoError(t, err)
+	var state struct {
+		Resources []struct {
+			Type      string `json:"type"`
+			Instances []struct {
+				SchemaVersion int `json:"schema_version"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal(migrated, &state))
+	checked := 0
+	for _, res := range state.Resources {
+		if res.Type != advCluster {
+			continue
+		}
+		for _, instance := range res.Instances {
+			assert.Equal(t, expected, instance.SchemaVersion, "run make update-schema if the provider schema changed")
+			checked++
+		}
+	}
+	assert.Positive(t, checked, "no %s migrated", advCluster)
+}
@@ -0,0 +1,254 @@
+package convert
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	internalhcl "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+)
+
+var (
+	providerMinVersion = version{2, 0, 0}
+	// movedMinVersion is the first Terraform version supporting moved blocks across resource types.
+	movedMinVersion = version{1, 8, 0}
+)
+
+// version is a semantic version, only major, minor and patch are supported.
+type version [3]int
+
+// UpdateProviderVersion changes the mongodbatlas version constraint in the required_providers blocks of a
+// Terraform configuration file to a range compatible with Provider 2.0.0. Other blocks are left untouched.
+// If includeMoved is true, a warning is added if required_version allows Terraform versions not supporting
+// moved blocks across resource types. It returns nil if the file doesn't need to be changed.
+func UpdateProviderVersion(config []byte, includeMoved bool) ([]byte, error) {
+	parser, err := internalhcl.GetParser(config)
+	if err != nil {
+		return nil, err
+	}
+	if !updateProviderVersion(parser.Body(), includeMoved) {
+		return nil, nil
+	}
+	return parser.Bytes(), nil
+}
+
+// updateProviderVersion updates the terraform blocks in body, it returns true if any was updated.
+func updateProviderVersion(body *hclwrite.Body, includeMoved bool) bool {
+	updated := false
+	for _, block := range body.Blocks() {
+		if block.Type() != nTerraform {
+			continue
+		}
+		blockb := block.Body()
+		var warnings []string
+		for _, providers := range blockb.Blocks() {
+			if providers.Type() != nRequiredProviders {
+				continue
+			}
+			if warning, found := updateProviderConstraint(providers.Body()); found {
+				if warning == "" {
+					addComments(block, true)
+					updated = true
+				} else {
+					warnings = append(warnings, warning)
+				}
+			}
+		}
+		if includeMoved && allowsVersionBefore(blockb.GetAttribute(nRequiredVersion), movedMinVersion) {
+			warnings = append(warnings, commentRequiredVersion)
+		}
+		for _, warning := range warnings {
+			if !strings.Contains(string(blockb.BuildTokens(nil).Bytes()), warning) { // already added in a previous run
+				internalhcl.AppendComment(blockb, warning)
+				updated = true
+			}
+		}
+	}
+	return updated
+}
+
+// updateProviderConstraint changes the mongodbatlas version constraint in place if it doesn't allow Provider 2.X.X.
+// It returns false if there is no constraint to update, or a warning if the constraint can't be updated.
+func updateProviderConstraint(providersb *hclwrite.Body) (warning string, found bool) {
+	for _, name := range slices.Sorted(maps.Keys(providersb.Attributes())) {
+		tokens := providersb.GetAttribute(name).Expr().BuildTokens(nil)
+		expr, diags := hclsyntax.ParseExpression(tokens.Bytes(), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			continue
+		}
+		versionExpr := expr
+		if obj, ok := expr.(*hclsyntax.ObjectConsExpr); ok {
+			source, _ := getLiteralString(getObjectItemExpr(obj, nSource))
+			if name != providerLocalName && !strings.HasSuffix(strings.ToLower(source), providerSource) {
+				continue
+			}
+			if versionExpr = getObjectItemExpr(obj, nVersion); versionExpr == nil {
+				return "", false // latest version is used if there is no constraint
+			}
+		} else if name != providerLocalName {
+			continue
+		}
+		constraint, ok := getLiteralString(versionExpr)
+		if !ok {
+			return fmt.Sprintf(commentProviderNotUpdated, providerLocalName, "it can't be evaluated statically"), true
+		}
+		constraints, err := parseConstraints(constraint)
+		if err != nil {
+			return fmt.Sprintf(commentProviderNotUpdated, providerLocalName, err), true
+		}
+		if allowsMajorVersion(constraints, providerMinVersion[0]) {
+			return "", false
+		}
+		setLiteralToken(tokens, versionExpr.Range(), providerConstraint)
+		return "", true
+	}
+	return "", false
+}
+
+// setLiteralToken changes in place the value of the quoted literal token in the byte range of a string expression.
+func setLiteralToken(tokens hclwrite.Tokens, rng hcl.Range, value string) {
+	offset := 0
+	for _, token := range tokens {
+		offset += token.SpacesBefore
+		if token.Type == hclsyntax.TokenQuotedLit && offset >= rng.Start.Byte && offset < rng.End.Byte {
+			token.Bytes = []byte(value)
+			return
+		}
+		offset += len(token.Bytes)
+	}
+}
+
+// getLiteralString returns the value of a string expression without interpolations.
+func getLiteralString(expr hclsyntax.Expression) (string, bool) {
+	if expr == nil {
+		return "", false
+	}
+	template, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok || !template.IsStringLiteral() {
+		return "", false
+	}
+	val, diags := template.Value(nil)
+	if diags.HasErrors() {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// allowsVersionBefore returns true if the constraint of a required_version attribute allows any Terraform version
+// before minVersion. Constraints that can't be evaluated are considered to allow it.
+func allowsVersionBefore(attr *hclwrite.Attribute, minVersion version) bool {
+	if attr == nil {
+		return false
+	}
+	expr, err := internalhcl.ParseAttrExpr(attr)
+	if err != nil {
+		return true
+	}
+	constraint, ok := getLiteralString(expr)
+	if !ok {
+		return true
+	}
+	constraints, err := parseConstraints(constraint)
+	if err != nil {
+		return true
+	}
+	candidates := []version{{minVersion[0], minVersion[1] - 1, 0}}
+	for _, c := range constraints {
+		if c.version.less(minVersion) {
+			candidates = append(candidates, c.version)
+		}
+	}
+	return slices.ContainsFunc(candidates, func(v version) bool { return v.less(minVersion) && constraints.allows(v) })
+}
+
+// allowsMajorVersion returns true if the constraints allow any version with the major version,
+// the versions in the constraints are the only candidates checked apart from <major>.0.0.
+func allowsMajorVersion(constraints versionConstraints, major int) bool {
+	candidates := []version{{major, 0, 0}}
+	for _, c := range constraints {
+		if c.version[0] == major {
+			candidates = append(candidates, c.version)
+		}
+	}
+	return slices.ContainsFunc(candidates, constraints.allows)
+}
+
+// versionConstraint is one of the comma-separated parts of a version constraint, e.g. >= 1.15.
+type versionConstraint struct {
+	op       string
+	version  version
+	segments int // number of segments in the version, used by the pessimistic operator ~>
+}
+
+type versionConstraints []versionConstraint
+
+// parseConstraints parses a version constraint like "~> 1.15, != 1.16.0", pre-release versions are not supported.
+func parseConstraints(constraint string) (versionConstraints, error) {
+	var ret versionConstraints
+	for part := range strings.SplitSeq(constraint, ",") {
+		part = strings.TrimSpace(part)
+		op := "="
+		for _, candidate := range []string{"~>", ">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				part = strings.TrimSpace(strings.TrimPrefix(part, candidate))
+				break
+			}
+		}
+		c := versionConstraint{op: op}
+		segments := strings.Split(strings.TrimPrefix(part, "v"), ".")
+		if len(segments) > len(c.version) {
+			return nil, fmt.Errorf("invalid version constraint %q", constraint)
+		}
+		for i, segment := range segments {
+			num, err := strconv.Atoi(segment)
+			if err != nil || num < 0 {
+				return nil, fmt.Errorf("invalid version constraint %q", constraint)
+			}
+			c.version[i] = num
+		}
+		c.segments = len(segments)
+		ret = append(ret, c)
+	}
+	return ret, nil
+}
+
+func (cs versionConstraints) allows(v version) bool {
+	for _, c := range cs {
+		if !c.allows(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c versionConstraint) allows(v version) bool {
+	switch c.op {
+	case ">=":
+		return !v.less(c.version)
+	case "<=":
+		return !c.version.less(v)
+	case ">":
+		return c.version.less(v)
+	case "<":
+		return v.less(c.version)
+	case "!=":
+		return v != c.version
+	case "~>": // e.g. ~> 1.15 allows 1.15 or later but not 2.0, ~> 1.15.2 allows 1.15.2 or later but not 1.16
+		if v.less(c.version) {
+			return false
+		}
+		prefix := max(c.segments-1, 1)
+		return slices.Equal(v[:prefix], c.version[:prefix])
+	}
+	return v == c.version
+}
+
+func (v version) less(other version) bool {
+	return slices.Compare(v[:], other[:]) < 0
+}
@@ -0,0 +1,273 @@
+package convert
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+)
+
+// advClusterObjectAttrs are the mongodbatlas_advanced_cluster blocks in provider 1.X.X (lists with one element)
+// that are single objects in provider 2.0.0, so the [0] index is not used to read them.
+var advClusterObjectAttrs = []string{
+	nElectableSpecs, nReadOnlySpecs, nAnalyticsSpecs, nAutoScaling, nAnalyticsAutoScaling,
+	nAdvConfig, nBiConnector, nPinnedFCV, nConnectionStrings,
+}
+
+// advClusterObjectTypes are the types whose references are updated in advancedClusterToV2.
+var advClusterObjectTypes = map[string][]string{
+	advCluster:       advClusterObjectAttrs,
+	advClusterPlural: advClusterObjectAttrs,
+}
+
+// traversalTokenTypes are the token types allowed in the traversals where references are replaced,
+// including literal and quoted indexes, e.g. [0] or ["key"], and splats.
+var traversalTokenTypes = []hclsyntax.TokenType{
+	hclsyntax.TokenIdent, hclsyntax.TokenDot, hclsyntax.TokenNumberLit, hclsyntax.TokenStar, hclsyntax.TokenOBrack,
+	hclsyntax.TokenCBrack, hclsyntax.TokenOQuote, hclsyntax.TokenQuotedLit, hclsyntax.TokenCQuote,
+}
+
+// replaceTokensFn returns the tokens of an expression with the references replaced, and true if any was replaced.
+type replaceTokensFn func(tokens hclwrite.Tokens) (hclwrite.Tokens, bool)
+
+// updateReferences replaces the references in the attributes of all blocks and their nested blocks.
+// Attributes with a name in renames are also renamed if any reference is replaced, and comment is added to the
+// updated blocks.
+func updateReferences(body *hclwrite.Body, replaceFn replaceTokensFn, renames map[string]string, comment string) {
+	for _, block := range body.Blocks() {
+		updateReferencesBody(block.Body(), replaceFn, renames, comment)
+	}
+}
+
+func updateReferencesBody(body *hclwrite.Body, replaceFn replaceTokensFn, renames map[string]string, comment string) {
+	updated := false
+	for _, name := range slices.Sorted(maps.Keys(body.Attributes())) {
+		tokens, replaced := replaceFn(body.GetAttribute(name).Expr().BuildTokens(nil))
+		if !replaced {
+			continue
+		}
+		updated = true
+		body.SetAttributeRaw(name, tokens)
+		if newName, found := renames[name]; found && body.GetAttribute(newName) == nil {
+			body.RenameAttribute(name, newName)
+		}
+	}
+	for _, block := range body.Blocks() {
+		updateReferencesBody(block.Body(), replaceFn, renames, comment)
+	}
+	if updated {
+		hcl.AppendComment(body, comment)
+	}
+}
+
+// updateRepSpecIDReferences changes the references to the replication_specs id of the converted clusters to zone_id
+// in all blocks, as replication_specs id is not available in mongodbatlas_advanced_cluster 2.0.0,
+// e.g. mongodbatlas_cluster.cluster.replication_specs.0.id to
+// mongodbatlas_advanced_cluster.cluster.replication_specs.0.zone_id.
+// replication_spec_id attributes, e.g. in copy_settings of mongodbatlas_cloud_backup_schedule, are renamed to zone_id.
+// References to resources of type fromType are changed if their name is in labels, or all of them if labels is nil.
+// Data sources are only changed if labels is nil.
+func updateRepSpecIDReferences(body *hclwrite.Body, fromType string, labels []string) {
+	replaceFn := func(tokens hclwrite.Tokens) (hclwrite.Tokens, bool) {
+		return tokens, replaceRepSpecIDTokens(tokens, fromType, labels)
+	}
+	updateReferences(body, replaceFn, map[string]string{nReplicationSpecID: nZoneID}, commentZoneIDUpdated)
+}
+
+// updateObjectReferences removes the [0] index when reading the attributes of resources and data sources that are
+// single objects in provider 2.0.0, objectAttrs has the attributes of each resource or data source type,
+// e.g. data.mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].electable_specs[0].node_count
+// to data.mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].electable_specs.node_count.
+func updateObjectReferences(body *hclwrite.Body, objectAttrs map[string][]string, comment string) {
+	replaceFn := func(tokens hclwrite.Tokens) (hclwrite.Tokens, bool) {
+		return replaceObjectIndexTokens(tokens, objectAttrs)
+	}
+	updateReferences(body, replaceFn, nil, comment)
+}
+
+// checkForReferences adds comment to the blocks with for expressions iterating over resources or data sources
+// whose object attributes are read with the [0] index through the iteration variables, as they can't be updated,
+// e.g. [for c in data.mongodbatlas_advanced_clusters.clusters.results : c.advanced_configuration[0].oplog_size_mb].
+// objectAttrs has the object attributes of each type, the comment is not added again if present.
+func checkForReferences(body *hclwrite.Body, objectAttrs map[string][]string, comment string) {
+	for _, block := range body.Blocks() {
+		checkForReferencesBody(block.Body(), objectAttrs, comment)
+	}
+}
+
+func checkForReferencesBody(body *hclwrite.Body, objectAttrs map[string][]string, comment string) {
+	found := false
+	for _, attr := range body.Attributes() {
+		if hasForObjectIndexTokens(attr.Expr().BuildTokens(nil), objectAttrs) {
+			found = true
+		}
+	}
+	for _, block := range body.Blocks() {
+		checkForReferencesBody(block.Body(), objectAttrs, comment)
+	}
+	if found && !hcl.HasComment(body, comment) {
+		hcl.AppendComment(body, comment)
+	}
+}
+
+// hasForObjectIndexTokens returns true if tokens have a for expression whose collection is a traversal starting with
+// a type in objectAttrs, optionally as a data source, followed by an object attribute of that type with a [0] or .0
+// index, e.g. for k, v in mongodbatlas_advanced_cluster.cluster : v.advanced_configuration[0].
+func hasForObjectIndexTokens(tokens hclwrite.Tokens, objectAttrs map[string][]string) bool {
+	const maxForVars = 3 // iteration variables with their comma, e.g. k, v
+	for i, token := range tokens {
+		if !isTokenIdent(token, nFor) {
+			continue
+		}
+		pos := i + 1
+		for pos < len(tokens) && pos <= i+maxForVars && !isTokenIdent(tokens[pos], nIn) {
+			pos++
+		}
+		if pos >= len(tokens) || !isTokenIdent(tokens[pos], nIn) {
+			continue
+		}
+		pos++
+		if pos+1 < len(tokens) && isTokenIdent(tokens[pos], dataSourceType) && tokens[pos+1].Type == hclsyntax.TokenDot {
+			pos += 2
+		}
+		if pos >= len(tokens) || tokens[pos].Type != hclsyntax.TokenIdent {
+			continue
+		}
+		attrs := objectAttrs[string(tokens[pos].Bytes)]
+		for j := pos + 1; attrs != nil && j < len(tokens); j++ {
+			if isTokenIdentIn(tokens[j], attrs) && getZeroIndexSize(tokens, j+1) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replaceRepSpecIDTokens replaces in place the traversals <fromType>.<label>[key].replication_specs[index].id with
+// mongodbatlas_advanced_cluster.<label>[key].replication_specs[index].zone_id, and returns true if any was replaced.
+// Index can also be in legacy form, e.g. replication_specs.0.id, or a splat, e.g. replication_specs[*].id.
+func replaceRepSpecIDTokens(tokens hclwrite.Tokens, fromType string, labels []string) bool {
+	replaced := false
+	for i := 0; i+2 < len(tokens); i++ {
+		if !isTokenIdent(tokens[i], fromType) || tokens[i+1].Type != hclsyntax.TokenDot ||
+			tokens[i+2].Type != hclsyntax.TokenIdent {
+			continue
+		}
+		isDataSource := i >= 2 && isTokenIdent(tokens[i-2], dataSourceType) && tokens[i-1].Type == hclsyntax.TokenDot
+		if labels != nil && (isDataSource || !slices.Contains(labels, string(tokens[i+2].Bytes))) {
+			continue
+		}
+		pos := skipTokensIndex(tokens, i+3)
+		if pos+1 >= len(tokens) || tokens[pos].Type != hclsyntax.TokenDot || !isTokenIdent(tokens[pos+1], nRepSpecs) {
+			continue
+		}
+		pos = skipTokensIndex(tokens, pos+2)
+		if pos+1 >= len(tokens) || tokens[pos].Type != hclsyntax.TokenDot || !isTokenIdent(tokens[pos+1], nID) {
+			continue
+		}
+		tokens[i].Bytes = []byte(advCluster)
+		tokens[pos+1].Bytes = []byte(nZoneID)
+		replaced = true
+	}
+	return replaced
+}
+
+// replaceTypeTokens replaces in place the types of the traversals of resources and data sources whose type is
+// renamed, e.g. mongodbatlas_old.name to mongodbatlas_new.name or data.mongodbatlas_old.name to
+// data.mongodbatlas_new.name. resourceTypes and dataSourceTypes have the new type of each renamed type.
+func replaceTypeTokens(tokens hclwrite.Tokens, resourceTypes, dataSourceTypes map[string]string) bool {
+	replaced := false
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokens[i].Type != hclsyntax.TokenIdent || tokens[i+1].Type != hclsyntax.TokenDot ||
+			tokens[i+2].Type != hclsyntax.TokenIdent {
+			continue
+		}
+		types := resourceTypes
+		if i >= 2 && isTokenIdent(tokens[i-2], dataSourceType) && tokens[i-1].Type == hclsyntax.TokenDot {
+			types = dataSourceTypes
+		} else if i >= 1 && tokens[i-1].Type == hclsyntax.TokenDot {
+			continue // attribute of another traversal, e.g. var.settings.mongodbatlas_old
+		}
+		if newType, found := types[string(tokens[i].Bytes)]; found {
+			tokens[i].Bytes = []byte(newType)
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+// replaceObjectIndexTokens returns the tokens without the [0] or .0 index after the object attributes of a type
+// in traversals starting with that type, e.g. mongodbatlas_advanced_cluster or mongodbatlas_advanced_clusters.
+func replaceObjectIndexTokens(tokens hclwrite.Tokens, objectAttrs map[string][]string) (hclwrite.Tokens, bool) {
+	var ret hclwrite.Tokens
+	replaced := false
+	var attrs []string // object attributes of the type of the current traversal, nil if not in a traversal
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token.Type == hclsyntax.TokenIdent && objectAttrs[string(token.Bytes)] != nil:
+			attrs = objectAttrs[string(token.Bytes)]
+		case attrs != nil && isTokenIdentIn(token, attrs):
+			if size := getZeroIndexSize(tokens, i+1); size > 0 {
+				ret = append(ret, token)
+				i += size
+				replaced = true
+				continue
+			}
+		case !slices.Contains(traversalTokenTypes, token.Type):
+			attrs = nil // index expressions with other tokens are not supported
+		}
+		ret = append(ret, token)
+	}
+	return ret, replaced
+}
+
+// getZeroIndexSize returns the number of tokens of a [0] or .0 index starting at pos, or 0 if there is none.
+func getZeroIndexSize(tokens hclwrite.Tokens, pos int) int {
+	if pos+1 < len(tokens) && tokens[pos].Type == hclsyntax.TokenDot && isTokenNumber(tokens[pos+1], "0") {
+		return 2
+	}
+	if pos+2 < len(tokens) && tokens[pos].Type == hclsyntax.TokenOBrack && isTokenNumber(tokens[pos+1], "0") &&
+		tokens[pos+2].Type == hclsyntax.TokenCBrack {
+		return 3
+	}
+	return 0
+}
+
+// skipTokensIndex returns the position after an index starting at pos, e.g. [each.key] or .0,
+// or pos if there is no index.
+func skipTokensIndex(tokens hclwrite.Tokens, pos int) int {
+	if pos+1 < len(tokens) && tokens[pos].Type == hclsyntax.TokenDot && tokens[pos+1].Type == hclsyntax.TokenNumberLit {
+		return pos + 2
+	}
+	if pos >= len(tokens) || tokens[pos].Type != hclsyntax.TokenOBrack {
+		return pos
+	}
+	depth := 0
+	for ; pos < len(tokens); pos++ {
+		switch tokens[pos].Type {
+		case hclsyntax.TokenOBrack:
+			depth++
+		case hclsyntax.TokenCBrack:
+			depth--
+			if depth == 0 {
+				return pos + 1
+			}
+		}
+	}
+	return pos
+}
+
+func isTokenIdent(token *hclwrite.Token, name string) bool {
+	return token.Type == hclsyntax.TokenIdent && string(token.Bytes) == name
+}
+
+func isTokenIdentIn(token *hclwrite.Token, names []string) bool {
+	return token.Type == hclsyntax.TokenIdent && slices.Contains(names, string(token.Bytes))
+}
+
+func isTokenNumber(token *hclwrite.Token, number string) bool {
+	return token.Type == hclsyntax.TokenNumberLit && string(token.Bytes) == number
+}
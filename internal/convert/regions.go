@@ -0,0 +1,133 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/regions"
+)
+
+// regionAttrs are the attributes of an object or body needed to check its region name.
+var regionAttrs = []string{nProviderName, nBackingProviderName, nRegionName}
+
+// checkRegions adds a comment to a converted resource for each literal region_name that is not an Atlas region of
+// its provider_name, or backing_provider_name for TENANT and flex clusters. If normalize is true, cloud-native names
+// like us-east-1 are changed to the Atlas names like US_EAST_1 instead.
+// Regions with expressions like var.region are not checked.
+func checkRegions(body *hclwrite.Body, normalize bool) {
+	var comments []string
+	for _, attrs := range getLiteralObjects(body.BuildTokens(nil), regionAttrs) {
+		regionToken := attrs[nRegionName]
+		if regionToken == nil {
+			continue
+		}
+		provider, region := getRegionProvider(attrs), string(regionToken.Bytes)
+		if name, ok := regions.Normalize(provider, region); ok && normalize {
+			regionToken.Bytes = []byte(name)
+			comments = append(comments, fmt.Sprintf(commentRegionNormalized, nRegionName, region, name))
+			continue
+		}
+		if issue := getRegionIssue(provider, region); issue != "" {
+			comments = append(comments, issue)
+		}
+	}
+	for _, comment := range comments {
+		hcl.AppendComment(body, comment)
+	}
+}
+
+// getRegionProvider returns the provider whose regions are used, the backing provider in TENANT and flex clusters.
+func getRegionProvider(attrs map[string]*hclwrite.Token) string {
+	var provider string
+	for _, name := range []string{nProviderName, nBackingProviderName} {
+		if token := attrs[name]; token != nil && (provider == "" || provider == nTenant) {
+			provider = string(token.Bytes)
+		}
+	}
+	return provider
+}
+
+// getAtlasRegionName returns the region_name of an evaluated region config, cloud-native names like us-east-1 are
+// normalized to the Atlas names so they're the same region in topologies.
+func getAtlasRegionName(config attrMap) string {
+	region := getString(config[nRegionName])
+	if name, ok := regions.Normalize(getRegionProviderName(config), region); ok {
+		return name
+	}
+	return region
+}
+
+// getRegionProviderName is like getRegionProvider for an evaluated region config.
+func getRegionProviderName(config attrMap) string {
+	if provider := getString(config[nProviderName]); provider != nTenant {
+		return provider
+	}
+	return getString(config[nBackingProviderName])
+}
+
+// getRegionIssue returns why region is not an Atlas region of the provider, suggesting the equivalent Atlas name,
+// the providers where it's valid or the closest match, in that order.
+// It returns an empty string if region is valid or the provider is not in the catalog.
+func getRegionIssue(provider, region string) string {
+	if !regions.HasProvider(provider) || regions.IsValid(provider, region) {
+		return ""
+	}
+	if name, ok := regions.Normalize(provider, region); ok {
+		return fmt.Sprintf(commentRegionSuggestion, nRegionName, region, provider, name)
+	}
+	if others := regions.FindProviders(region); len(others) > 0 {
+		return fmt.Sprintf(commentRegionProvider, nRegionName, region, provider, strings.Join(others, ", "))
+	}
+	if suggestion := regions.Suggest(provider, region); suggestion != "" {
+		return fmt.Sprintf(commentRegionSuggestion, nRegionName, region, provider, suggestion)
+	}
+	return fmt.Sprintf(commentRegionInvalid, nRegionName, region, provider)
+}
+
+// getLiteralObjects returns the string literal tokens of the names in each object and body in tokens,
+// e.g. each object in region_configs. Objects without any of the names are not returned.
+func getLiteralObjects(tokens hclwrite.Tokens, names []string) []map[string]*hclwrite.Token {
+	var ret []map[string]*hclwrite.Token
+	stack := []map[string]*hclwrite.Token{{}} // body is the root object
+	pop := func() {
+		if top := stack[len(stack)-1]; len(top) > 0 {
+			ret = append(ret, top)
+		}
+		stack = stack[:len(stack)-1]
+	}
+	for i, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenOBrace:
+			stack = append(stack, map[string]*hclwrite.Token{})
+		case hclsyntax.TokenCBrace:
+			if len(stack) > 1 {
+				pop()
+			}
+		case hclsyntax.TokenIdent:
+			if value := getStringLiteral(tokens[i+1:]); value != nil && slices.Contains(names, string(token.Bytes)) {
+				stack[len(stack)-1][string(token.Bytes)] = value
+			}
+		}
+	}
+	for len(stack) > 0 {
+		pop()
+	}
+	return ret
+}
+
+// getStringLiteral returns the token with the value of an assignment of a string without interpolations,
+// e.g. = "US_EAST_1", or nil if tokens don't start with it.
+func getStringLiteral(tokens hclwrite.Tokens) *hclwrite.Token {
+	const assignmentLen = 4 // equal, open quote, literal and close quote
+	if len(tokens) < assignmentLen ||
+		(tokens[0].Type != hclsyntax.TokenEqual && tokens[0].Type != hclsyntax.TokenColon) ||
+		tokens[1].Type != hclsyntax.TokenOQuote || tokens[2].Type != hclsyntax.TokenQuotedLit ||
+		tokens[3].Type != hclsyntax.TokenCQuote {
+		return nil
+	}
+	return tokens[2]
+}
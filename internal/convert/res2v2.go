@@ -0,0 +1,273 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/schema"
+)
+
+// typeObject is the name of the object attribute type in the provider schema.
+const typeObject = "object"
+
+// metaBlocks are the root blocks of resources and data sources defined by Terraform.
+var metaBlocks = []string{nLifecycle, "provisioner", "connection"}
+
+// ResourcesToV2 transforms the blocks that are attributes in Provider 2.0.0 in all mongodbatlas resources and
+// data sources of a Terraform configuration file, using the embedded snapshot of the provider schema.
+// mongodbatlas_advanced_cluster is left untouched as it's transformed by AdvancedClusterToV2, as well as the
+// resources and data sources not in the snapshot, a warning comment is added to the mongodbatlas ones with blocks.
+func ResourcesToV2(config []byte, opts Options) ([]byte, error) {
+	parser, err := hcl.GetParser(config)
+	if err != nil {
+		return nil, err
+	}
+	parserb := parser.Body()
+	for _, block := range parserb.Blocks() {
+		blockSchema := getBlockSchema(block)
+		if blockSchema == nil {
+			checkSchemaNotFound(block)
+			continue
+		}
+		updated, err := convertNestedBlocks(block.Body(), blockSchema.Attributes, blockSchema.BlockTypes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", getBlockAddress(block), err)
+		}
+		if updated {
+			addComments(block, true)
+		}
+	}
+	updateObjectReferences(parserb, getSchemaObjectAttrs(), commentNestedObjectUpdated)
+	if opts.UpdateProvider {
+		updateProviderVersion(parserb, false)
+	}
+	return parser.Bytes(), nil
+}
+
+// getBlockSchema returns the schema of a resource or data source block, or nil if it's not converted.
+func getBlockSchema(block *hclwrite.Block) *schema.Block {
+	name := getResourceName(block)
+	if name == advCluster || name == advClusterPlural {
+		return nil
+	}
+	switch block.Type() {
+	case resourceType:
+		return schema.Resource(name)
+	case dataSourceType:
+		return schema.DataSource(name)
+	}
+	return nil
+}
+
+// checkSchemaNotFound adds a warning comment to the mongodbatlas resources and data sources with blocks whose type
+// is not in the schema snapshot, as their blocks can't be converted. The comment is not added again if present.
+func checkSchemaNotFound(block *hclwrite.Block) {
+	if !isSchemaNotFound(block) || !slices.ContainsFunc(block.Body().Blocks(), func(nested *hclwrite.Block) bool {
+		return !slices.Contains(metaBlocks, nested.Type())
+	}) {
+		return
+	}
+	if comment := fmt.Sprintf(commentSchemaNotFound, getResourceName(block)); !hcl.HasComment(block.Body(), comment) {
+		hcl.AppendComment(block.Body(), comment)
+	}
+}
+
+// isSchemaNotFound returns true for mongodbatlas resources and data sources not in the schema snapshot,
+// mongodbatlas_advanced_cluster is not included as it's converted by AdvancedClusterToV2.
+func isSchemaNotFound(block *hclwrite.Block) bool {
+	name := getResourceName(block)
+	if !strings.HasPrefix(name, providerTypePrefix) || name == advCluster || name == advClusterPlural {
+		return false
+	}
+	switch block.Type() {
+	case resourceType:
+		return schema.Resource(name) == nil
+	case dataSourceType:
+		return schema.DataSource(name) == nil
+	}
+	return false
+}
+
+// convertNestedBlocks converts the blocks in body that are attributes in the schema, including the nested blocks
+// inside them. Blocks that are still blocks in the schema are kept, but their nested blocks are also converted.
+// It returns true if any block was converted.
+func convertNestedBlocks(body *hclwrite.Body, attrs map[string]*schema.Attribute,
+	blockTypes map[string]*schema.BlockType) (bool, error) {
+	updated := false
+	for _, name := range getBlockNames(body) {
+		if attr := attrs[name]; attr != nil {
+			if err := fillNestedAttr(body, name, attr); err != nil {
+				return false, err
+			}
+			updated = true
+			continue
+		}
+		blockType := blockTypes[name]
+		if blockType == nil || blockType.Block == nil {
+			continue
+		}
+		for _, nestedb := range getNestedBodies(body, name) {
+			nestedUpdated, err := convertNestedBlocks(nestedb, blockType.Block.Attributes, blockType.Block.BlockTypes)
+			if err != nil {
+				return false, err
+			}
+			updated = updated || nestedUpdated
+		}
+	}
+	return updated, nil
+}
+
+// fillNestedAttr converts the blocks with the name to an attribute according to its type in the schema.
+func fillNestedAttr(body *hclwrite.Body, name string, attr *schema.Attribute) error {
+	var nestedAttrs map[string]*schema.Attribute
+	nesting := attr.TypeName()
+	if attr.NestedType != nil {
+		nestedAttrs = attr.NestedType.Attributes
+		nesting = attr.NestedType.NestingMode
+	}
+	switch {
+	case nesting == schema.NestingMap && attr.NestedType == nil: // map of strings from key and value blocks
+		return fillTagsLabelsOpt(body, name)
+	case nesting == schema.NestingSingle || nesting == typeObject:
+		return fillNestedObject(body, name, nestedAttrs)
+	case nesting == schema.NestingList || nesting == schema.NestingSet:
+		return fillNestedList(body, name, nestedAttrs)
+	}
+	return fmt.Errorf("blocks %s can't be converted to an attribute of type %s", name, nesting)
+}
+
+func fillNestedObject(body *hclwrite.Body, name string, nestedAttrs map[string]*schema.Attribute) error {
+	d, err := getDynamicBlock(body, name, false)
+	if err != nil {
+		return err
+	}
+	if d.IsPresent() {
+		return fmt.Errorf("dynamic block %s can't be converted to an object", name)
+	}
+	blocks := collectBlocks(body, name)
+	if len(blocks) > 1 {
+		return fmt.Errorf("%s: only one block is allowed to convert it to an object", name)
+	}
+	blockb := blocks[0].Body()
+	if _, err := convertNestedBlocks(blockb, nestedAttrs, nil); err != nil {
+		return err
+	}
+	body.SetAttributeRaw(name, hcl.TokensObject(blockb))
+	return nil
+}
+
+func fillNestedList(body *hclwrite.Body, name string, nestedAttrs map[string]*schema.Attribute) error {
+	d, err := getDynamicBlock(body, name, true)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if d.IsPresent() {
+		return fillNestedListDynamic(body, name, nestedAttrs, d)
+	}
+	blocks := collectBlocks(body, name)
+	bodies := make([]*hclwrite.Body, 0, len(blocks))
+	for _, block := range blocks {
+		blockb := block.Body()
+		if _, err := convertNestedBlocks(blockb, nestedAttrs, nil); err != nil {
+			return err
+		}
+		bodies = append(bodies, blockb)
+	}
+	body.SetAttributeRaw(name, hcl.TokensArray(bodies))
+	return nil
+}
+
+// fillNestedListDynamic converts a dynamic block to a for expression, e.g. dynamic "specs" with for_each = var.specs
+// and specs.value.instance_size in content to [for specs in var.specs : { instance_size = specs.instance_size }].
+func fillNestedListDynamic(body *hclwrite.Body, name string, nestedAttrs map[string]*schema.Attribute,
+	d dynamicBlock) error {
+	varName := name
+	if iterator := d.block.Body().GetAttribute(nIterator); iterator != nil {
+		varName = hcl.GetAttrExpr(iterator)
+	}
+	contentb := d.content.Body()
+	transformReferences(contentb, varName, varName)
+	if _, err := convertNestedBlocks(contentb, nestedAttrs, nil); err != nil {
+		return err
+	}
+	tokens := hcl.TokensFromExpr(buildForExpr(varName, hcl.GetAttrExpr(d.forEach), false))
+	tokens = append(tokens, hcl.TokensObject(contentb)...)
+	body.RemoveBlock(d.block)
+	body.SetAttributeRaw(name, hcl.EncloseBracketsNewLines(tokens))
+	return nil
+}
+
+// getBlockNames returns the names of the blocks in body in order of appearance without duplicates,
+// the name of dynamic blocks is their label.
+func getBlockNames(body *hclwrite.Body) []string {
+	var ret []string
+	for _, block := range body.Blocks() {
+		name := block.Type()
+		if name == nDynamic {
+			name = getResourceName(block)
+		}
+		if !slices.Contains(ret, name) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// getNestedBodies returns the bodies of the blocks with the name, including the content of dynamic blocks.
+func getNestedBodies(body *hclwrite.Body, name string) []*hclwrite.Body {
+	var ret []*hclwrite.Body
+	for _, block := range body.Blocks() {
+		switch {
+		case block.Type() == name:
+			ret = append(ret, block.Body())
+		case block.Type() == nDynamic && getResourceName(block) == name:
+			if content := block.Body().FirstMatchingBlock(nContent, nil); content != nil {
+				ret = append(ret, content.Body())
+			}
+		}
+	}
+	return ret
+}
+
+// getSchemaObjectAttrs returns the attributes that are single objects in the schema for each resource and data source
+// type in the snapshot, except mongodbatlas_advanced_cluster.
+func getSchemaObjectAttrs() map[string][]string {
+	ret := make(map[string][]string)
+	add := func(name string, block *schema.Block) {
+		if name == advCluster || name == advClusterPlural {
+			return
+		}
+		if attrs := appendObjectAttrs(ret[name], block.Attributes, block.BlockTypes); len(attrs) > 0 {
+			ret[name] = attrs
+		}
+	}
+	for _, name := range schema.ResourceTypes() {
+		add(name, schema.Resource(name))
+	}
+	for _, name := range schema.DataSourceTypes() {
+		add(name, schema.DataSource(name))
+	}
+	return ret
+}
+
+func appendObjectAttrs(names []string, attrs map[string]*schema.Attribute,
+	blockTypes map[string]*schema.BlockType) []string {
+	for name, attr := range attrs {
+		if attr.NestedType == nil {
+			continue
+		}
+		if attr.NestedType.NestingMode == schema.NestingSingle && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+		names = appendObjectAttrs(names, attr.NestedType.Attributes, nil)
+	}
+	for _, blockType := range blockTypes {
+		if blockType.Block != nil {
+			names = appendObjectAttrs(names, blockType.Block.Attributes, blockType.Block.BlockTypes)
+		}
+	}
+	return names
+}
@@ -0,0 +1,276 @@
+package convert
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	internalhcl "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+)
+
+// Rule is a declarative migration of the resources, data sources or module calls matching one of its selectors,
+// loaded from a rules file. Attribute and block names can be paths to nested blocks, e.g. replication_specs.region.
+type Rule struct {
+	RenameAttributes map[string]string `hcl:"rename_attributes,optional"`
+	Name             string            `hcl:"name,label"`
+	ResourceType     string            `hcl:"resource_type,optional"`    // selector for resources
+	DataSourceType   string            `hcl:"data_source_type,optional"` // selector for data sources
+	ModuleSource     string            `hcl:"module_source,optional"`    // selector for module calls
+	RenameType       string            `hcl:"rename_type,optional"`
+	RemoveAttributes []string          `hcl:"remove_attributes,optional"`
+	BlocksToObject   []string          `hcl:"blocks_to_object,optional"` // block converted to an object attribute
+	BlocksToList     []string          `hcl:"blocks_to_list,optional"`   // blocks converted to a list attribute
+	BlocksToMap      []string          `hcl:"blocks_to_map,optional"`    // key/value blocks converted to a map
+	Moved            bool              `hcl:"moved,optional"`            // moved blocks for the renamed resources
+}
+
+type rulesFile struct {
+	Rules []Rule `hcl:"rule,block"`
+}
+
+// blockOperation converts the blocks with the last name in path, e.g. fillBlockOpt.
+type blockOperation struct {
+	fill func(body *hclwrite.Body, name string) error
+	path []string
+}
+
+// LoadRules parses and validates a rules file in HCL format, filename is only used in the error messages.
+func LoadRules(content []byte, filename string) ([]Rule, error) {
+	file, diags := hclsyntax.ParseConfig(content, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse rules file: %s", diags.Error())
+	}
+	var rf rulesFile
+	if diags := gohcl.DecodeBody(file.Body, nil, &rf); diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse rules file: %s", diags.Error())
+	}
+	for i := range rf.Rules {
+		if err := validateRule(&rf.Rules[i]); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rf.Rules[i].Name, err)
+		}
+	}
+	return rf.Rules, nil
+}
+
+func validateRule(rule *Rule) error {
+	selectors := 0
+	for _, selector := range []string{rule.ResourceType, rule.DataSourceType, rule.ModuleSource} {
+		if selector != "" {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return errors.New("exactly one of resource_type, data_source_type or module_source must be set")
+	}
+	if rule.ModuleSource != "" && rule.RenameType != "" {
+		return errors.New("rename_type can't be used with module_source")
+	}
+	if rule.RenameType != "" && !hclsyntax.ValidIdentifier(rule.RenameType) {
+		return fmt.Errorf("invalid rename_type %q", rule.RenameType)
+	}
+	if rule.Moved && (rule.ResourceType == "" || rule.RenameType == "") {
+		return errors.New("moved can only be used with resource_type and rename_type")
+	}
+	paths := slices.Concat(rule.RemoveAttributes, rule.BlocksToObject, rule.BlocksToList, rule.BlocksToMap,
+		slices.Collect(maps.Keys(rule.RenameAttributes)))
+	for _, path := range paths {
+		for name := range strings.SplitSeq(path, ".") {
+			if !hclsyntax.ValidIdentifier(name) {
+				return fmt.Errorf("invalid path %q", path)
+			}
+		}
+	}
+	for _, newName := range rule.RenameAttributes {
+		if !hclsyntax.ValidIdentifier(newName) {
+			return fmt.Errorf("invalid attribute name %q in rename_attributes", newName)
+		}
+	}
+	return nil
+}
+
+// ApplyRules applies the rules to the matching blocks of a Terraform configuration file, in the order they're defined.
+// All rules matching a block are applied, the match is done before any rule changes it.
+// References to the resources and data sources whose type is renamed are updated, and moved blocks are added for
+// the renamed resources if the rule has moved = true.
+func ApplyRules(config []byte, rules []Rule) ([]byte, error) {
+	if len(rules) == 0 {
+		return config, nil
+	}
+	parser, err := internalhcl.GetParser(config)
+	if err != nil {
+		return nil, err
+	}
+	parserb := parser.Body()
+	renamedTypes := map[string]map[string]string{resourceType: {}, dataSourceType: {}} // from original to new type
+	moved := make(map[string][]*hclwrite.Block)
+	var movedTypes []string
+	for _, block := range parserb.Blocks() {
+		var matching []*Rule
+		for i := range rules {
+			if isRuleMatch(&rules[i], block) {
+				matching = append(matching, &rules[i])
+			}
+		}
+		fromType := getResourceName(block)
+		isMoved := false
+		for _, rule := range matching {
+			if err := applyRule(rule, block); err != nil {
+				return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+			}
+			isMoved = isMoved || rule.Moved
+		}
+		toType := getResourceName(block)
+		if len(matching) == 0 || toType == fromType {
+			continue
+		}
+		renamedTypes[block.Type()][fromType] = toType
+		if isMoved && getResourceLabel(block) != "" {
+			if !slices.Contains(movedTypes, fromType) {
+				movedTypes = append(movedTypes, fromType)
+			}
+			moved[fromType] = append(moved[fromType], block)
+		}
+	}
+	replaceFn := func(tokens hclwrite.Tokens) (hclwrite.Tokens, bool) {
+		return tokens, replaceTypeTokens(tokens, renamedTypes[resourceType], renamedTypes[dataSourceType])
+	}
+	updateReferences(parserb, replaceFn, nil, commentRuleReferences)
+	for _, fromType := range movedTypes {
+		fillMovedBlocks(parserb, fromType, moved[fromType], commentRuleMovedNote)
+	}
+	return parser.Bytes(), nil
+}
+
+func isRuleMatch(rule *Rule, block *hclwrite.Block) bool {
+	switch block.Type() {
+	case resourceType:
+		return rule.ResourceType != "" && getResourceName(block) == rule.ResourceType
+	case dataSourceType:
+		return rule.DataSourceType != "" && getResourceName(block) == rule.DataSourceType
+	case nModule:
+		sourceAttr := block.Body().GetAttribute(nSource)
+		if rule.ModuleSource == "" || sourceAttr == nil {
+			return false
+		}
+		source, err := internalhcl.GetAttrString(sourceAttr)
+		return err == nil && source == rule.ModuleSource
+	}
+	return false
+}
+
+// applyRule removes and renames the attributes first, and then converts the blocks starting with the most nested
+// ones, so the paths of the outer blocks are still valid.
+func applyRule(rule *Rule, block *hclwrite.Block) error {
+	blockb := block.Body()
+	var removed []string
+	for _, path := range rule.RemoveAttributes {
+		parts := strings.Split(path, ".")
+		for _, body := range getPathBodies(blockb, parts[:len(parts)-1]) {
+			if body.GetAttribute(parts[len(parts)-1]) != nil {
+				body.RemoveAttribute(parts[len(parts)-1])
+				removed = append(removed, path)
+			}
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(rule.RenameAttributes)) {
+		parts := strings.Split(path, ".")
+		for _, body := range getPathBodies(blockb, parts[:len(parts)-1]) {
+			if body.GetAttribute(parts[len(parts)-1]) != nil {
+				body.RenameAttribute(parts[len(parts)-1], rule.RenameAttributes[path])
+			}
+		}
+	}
+	var operations []blockOperation
+	for _, path := range rule.BlocksToMap {
+		operations = append(operations, blockOperation{path: strings.Split(path, "."), fill: fillTagsLabelsOpt})
+	}
+	for _, path := range rule.BlocksToList {
+		operations = append(operations, blockOperation{path: strings.Split(path, "."), fill: fillBlocksList})
+	}
+	for _, path := range rule.BlocksToObject {
+		operations = append(operations, blockOperation{path: strings.Split(path, "."), fill: fillBlockObject})
+	}
+	slices.SortStableFunc(operations, func(a, b blockOperation) int { return cmp.Compare(len(b.path), len(a.path)) })
+	for _, op := range operations {
+		for _, body := range getPathBodies(blockb, op.path[:len(op.path)-1]) {
+			if err := op.fill(body, op.path[len(op.path)-1]); err != nil {
+				return err
+			}
+		}
+	}
+	if rule.RenameType != "" {
+		setResourceName(block, rule.RenameType)
+	}
+	blockb.AppendNewline()
+	for _, path := range removed {
+		internalhcl.AppendComment(blockb, fmt.Sprintf(commentRuleRemoved, path, rule.Name))
+	}
+	internalhcl.AppendComment(blockb, fmt.Sprintf(commentRuleApplied, rule.Name))
+	return nil
+}
+
+// fillBlockObject converts a block to an object attribute, like fillBlockOpt but failing if there are
+// multiple or dynamic blocks.
+func fillBlockObject(body *hclwrite.Body, name string) error {
+	if err := checkRuleDynamicBlock(body, name); err != nil {
+		return err
+	}
+	if len(getBlocks(body, name)) > 1 {
+		return fmt.Errorf("%s: only one block is allowed to convert it to an object", name)
+	}
+	fillBlockOpt(body, name)
+	return nil
+}
+
+// fillBlocksList converts all the blocks with the name to a list attribute.
+func fillBlocksList(body *hclwrite.Body, name string) error {
+	if err := checkRuleDynamicBlock(body, name); err != nil {
+		return err
+	}
+	blocks := collectBlocks(body, name)
+	if len(blocks) == 0 {
+		return nil
+	}
+	bodies := make([]*hclwrite.Body, 0, len(blocks))
+	for _, block := range blocks {
+		bodies = append(bodies, block.Body())
+	}
+	body.SetAttributeRaw(name, internalhcl.TokensArray(bodies))
+	return nil
+}
+
+func checkRuleDynamicBlock(body *hclwrite.Body, name string) error {
+	if body.FirstMatchingBlock(nDynamic, []string{name}) != nil {
+		return fmt.Errorf("dynamic blocks are not supported for %s", name)
+	}
+	return nil
+}
+
+// getPathBodies returns the bodies of all the nested blocks in the path, or body itself if path is empty.
+func getPathBodies(body *hclwrite.Body, path []string) []*hclwrite.Body {
+	if len(path) == 0 {
+		return []*hclwrite.Body{body}
+	}
+	var ret []*hclwrite.Body
+	for _, block := range getBlocks(body, path[0]) {
+		ret = append(ret, getPathBodies(block.Body(), path[1:])...)
+	}
+	return ret
+}
+
+func getBlocks(body *hclwrite.Body, name string) []*hclwrite.Block {
+	var ret []*hclwrite.Block
+	for _, block := range body.Blocks() {
+		if block.Type() == name {
+			ret = append(ret, block)
+		}
+	}
+	return ret
+}
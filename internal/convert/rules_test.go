@@ -0,0 +1,25 @@
+package convert_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplyRules applies the rules in the file with suffix .rules.hcl to the input file of each test.
+func TestApplyRules(t *testing.T) {
+	const cmdName = "rules"
+	runConvertTests(t, cmdName, func(testName string, inConfig []byte) ([]byte, error) {
+		filename := filepath.Join("testdata", cmdName, testName+".rules.hcl")
+		content, err := afero.ReadFile(afero.NewOsFs(), filename)
+		require.NoError(t, err)
+		rules, err := convert.LoadRules(content, filename)
+		if err != nil {
+			return nil, err
+		}
+		return convert.ApplyRules(inConfig, rules)
+	})
+}
@@ -0,0 +1,76 @@
+package convert
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+)
+
+// ServerlessToFlex transforms all mongodbatlas_serverless_instance resource and data source definitions in a
+// Terraform configuration file into mongodbatlas_flex_cluster. mongodbatlas_serverless_instances data sources are
+// transformed into mongodbatlas_flex_clusters.
+// All other resources and data sources are left untouched.
+func ServerlessToFlex(config []byte, opts Options) ([]byte, error) {
+	parser, err := hcl.GetParser(config)
+	if err != nil {
+		return nil, err
+	}
+	var movedResources []*hclwrite.Block
+	parserb := parser.Body()
+	for _, block := range parserb.Blocks() {
+		convertedResource, err := convertServerlessResource(block)
+		if err != nil {
+			return nil, err
+		}
+		if opts.IncludeMoved && convertedResource && getResourceLabel(block) != "" {
+			movedResources = append(movedResources, block)
+		}
+		if convertedResource {
+			checkRegions(block.Body(), opts.NormalizeRegions)
+		}
+		convertedDataSource := convertServerlessDataSource(block)
+		if convertedResource || convertedDataSource {
+			addComments(block, false)
+		}
+	}
+	fillMovedBlocks(parserb, serverless, movedResources, commentRemovedOld)
+	return parser.Bytes(), nil
+}
+
+func convertServerlessResource(block *hclwrite.Block) (bool, error) {
+	if block.Type() != resourceType || getResourceName(block) != serverless {
+		return false, nil
+	}
+	setResourceName(block, flexCluster)
+	blockb := block.Body()
+	settingsb := hclwrite.NewEmptyFile().Body()
+	if err := hcl.MoveAttr(blockb, settingsb, nSettingsBackingProvider, nBackingProviderName, errServerless); err != nil {
+		return false, err
+	}
+	if err := hcl.MoveAttr(blockb, settingsb, nSettingsRegionName, nRegionName, errServerless); err != nil {
+		return false, err
+	}
+	blockb.RemoveAttribute(nSettingsProviderName) // always SERVERLESS
+	var comments []string
+	if blockb.RemoveAttribute(nContinuousBackup) != nil {
+		comments = append(comments, commentContinuousBackup)
+	}
+	if err := fillFlexCluster(blockb, settingsb, comments...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func convertServerlessDataSource(block *hclwrite.Block) bool {
+	if block.Type() != dataSourceType {
+		return false
+	}
+	convertMap := map[string]string{
+		serverless:       flexCluster,
+		serverlessPlural: flexClusterPlural,
+	}
+	if newName, found := convertMap[getResourceName(block)]; found {
+		setResourceName(block, newName)
+		return true
+	}
+	return false
+}
@@ -16,6 +16,21 @@ var (
 	errDynamicBlockAlone = errors.New("dynamic block must be the only block, see docs for more information")
 )
 
+// Options contains the optional behaviors of the conversion commands.
+// Options not relevant for a command are ignored.
+type Options struct {
+	IncludeMoved     bool // include moved blocks for the converted resources
+	CompactShards    bool // use a for expression instead of repeating the replication_specs for literal num_shards
+	ExtractLocals    bool // move generated expressions like replication_specs from dynamic blocks to locals
+	KeepOriginal     bool // keep a commented out copy of the original resources
+	IncludeRemoved   bool // include removed and import blocks for the converted resources
+	IncludeImport    bool // include import blocks for the converted resources
+	IncludeCheck     bool // include check blocks asserting the topology of the converted resources
+	ConvertFlex      bool // convert shared-tier clusters (M2 and M5) to flex clusters instead of advanced clusters
+	UpdateProvider   bool // update the mongodbatlas version constraint in required_providers to Provider 2.X.X
+	NormalizeRegions bool // change cloud-native region names like us-east-1 to Atlas region names like US_EAST_1
+}
+
 // addComments adds appropriate comments to a converted block
 func addComments(block *hclwrite.Block, isUpdatedComment bool) {
 	blockb := block.Body()
@@ -28,6 +43,48 @@ func addComments(block *hclwrite.Block, isUpdatedComment bool) {
 	}
 }
 
+// appendTokensAfter appends the tokens to add after a block, separated by a blank line from the previous ones.
+func appendTokensAfter(tokens, newTokens hclwrite.Tokens) hclwrite.Tokens {
+	if len(tokens) > 0 {
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	return append(tokens, newTokens...)
+}
+
+// extractLocals moves the generated replication_specs expression of a resource to a locals block,
+// e.g. resource "mongodbatlas_advanced_cluster" "cluster" uses local.cluster_replication_specs.
+// List literals are not moved, and neither are expressions referencing each, count or self
+// as they're only valid inside the resource.
+func extractLocals(resource *hclwrite.Block) *hclwrite.Block {
+	resourceb := resource.Body()
+	attr := resourceb.GetAttribute(nRepSpecs)
+	label := getResourceLabel(resource)
+	if attr == nil || label == "" || !isLocalCandidate(attr) {
+		return nil
+	}
+	localName := fmt.Sprintf("%s_%s", label, nRepSpecs)
+	locals := hclwrite.NewBlock(nLocals, nil)
+	locals.Body().SetAttributeRaw(localName, attr.Expr().BuildTokens(nil))
+	resourceb.SetAttributeRaw(nRepSpecs, hcl.TokensFromExpr(fmt.Sprintf("%s.%s", nLocal, localName)))
+	return locals
+}
+
+func isLocalCandidate(attr *hclwrite.Attribute) bool {
+	expr, err := hcl.ParseAttrExpr(attr)
+	if err != nil {
+		return false
+	}
+	if _, isList := expr.(*hclsyntax.TupleConsExpr); isList {
+		return false
+	}
+	for _, traversal := range expr.Variables() {
+		if slices.Contains([]string{nEach, nCount, nSelf}, traversal.RootName()) {
+			return false
+		}
+	}
+	return true
+}
+
 // hasVariableNumShards checks if any block has a variable (non-literal) num_shards attribute
 func hasVariableNumShards(blocks []*hclwrite.Block) bool {
 	for _, block := range blocks {
@@ -40,19 +97,36 @@ func hasVariableNumShards(blocks []*hclwrite.Block) bool {
 	return false
 }
 
-// processNumShardsWhenSomeIsVariable handles num_shards when some replication_specs have variable num_shards
-func processNumShardsWhenSomeIsVariable(shardsAttr *hclwrite.Attribute, processedBody *hclwrite.Body) hclwrite.Tokens {
+// hasMultipleNumShards checks if any block has a literal num_shards attribute greater than 1
+func hasMultipleNumShards(blocks []*hclwrite.Block) bool {
+	for _, block := range blocks {
+		if shardsAttr := block.Body().GetAttribute(nNumShards); shardsAttr != nil {
+			if shardsVal, err := hcl.GetAttrInt(shardsAttr, errNumShards); err == nil && shardsVal > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// processNumShardsTokens handles num_shards when replication_specs are generated as a concatenation of lists,
+// e.g. when some replication_specs have variable num_shards or when compact shards option is used.
+func processNumShardsTokens(shardsAttr *hclwrite.Attribute, processedBody *hclwrite.Body,
+	compactShards bool) hclwrite.Tokens {
 	if shardsAttr == nil {
 		return hcl.TokensArraySingle(processedBody) // Default 1 if no num_shards specified
 	}
+	shardsExpr := hcl.GetAttrExpr(shardsAttr)
 	if shardsVal, err := hcl.GetAttrInt(shardsAttr, errNumShards); err == nil {
-		var bodies []*hclwrite.Body
-		for range shardsVal {
-			bodies = append(bodies, processedBody)
+		if !compactShards || shardsVal <= 1 {
+			var bodies []*hclwrite.Body
+			for range shardsVal {
+				bodies = append(bodies, processedBody)
+			}
+			return hcl.TokensArray(bodies)
 		}
-		return hcl.TokensArray(bodies)
+		shardsExpr = strconv.Itoa(shardsVal)
 	}
-	shardsExpr := hcl.GetAttrExpr(shardsAttr)
 	tokens := hcl.TokensFromExpr(buildForExpr("i", fmt.Sprintf("range(%s)", shardsExpr), false))
 	tokens = append(tokens, hcl.TokensObject(processedBody)...)
 	return hcl.EncloseBracketsNewLines(tokens)
@@ -0,0 +1,215 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	internalhcl "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// StateMigration contains the information to migrate the state of a cluster instance without moved blocks.
+type StateMigration struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	ImportID string `json:"importId"`
+	Note     string `json:"note,omitempty"`
+	Resolved bool   `json:"resolved"`
+}
+
+// instanceKey is the key of a resource instance when count (number key) or for_each (string key) are used,
+// value is only used in for_each.
+type instanceKey struct {
+	key   cty.Value
+	value cty.Value
+}
+
+// ClusterStateMigrations returns the state migrations for all mongodbatlas_cluster resource instances in a
+// Terraform configuration file, so they can be migrated with terraform state rm and terraform import commands.
+// modulePath is the optional module address of the configuration file, e.g. module.db.
+// Shared-tier clusters are migrated to mongodbatlas_flex_cluster if opts.ConvertFlex is set, like in the conversion.
+// Instances and import ids are resolved when count, for_each, project_id and name can be evaluated statically,
+// otherwise the migration is returned as not resolved so it can be completed manually.
+func ClusterStateMigrations(config []byte, modulePath string, opts Options) ([]StateMigration, error) {
+	parser, err := internalhcl.GetParser(config)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if modulePath != "" {
+		prefix = modulePath + "."
+	}
+	var ret []StateMigration
+	for _, block := range parser.Body().Blocks() {
+		label := getResourceLabel(block)
+		if block.Type() != resourceType || getResourceName(block) != cluster || label == "" {
+			continue
+		}
+		from := fmt.Sprintf("%s%s.%s", prefix, cluster, label)
+		targetType := advCluster
+		if opts.ConvertFlex && isSharedTierCluster(block.Body()) {
+			targetType = flexCluster
+		}
+		to := fmt.Sprintf("%s%s.%s", prefix, targetType, label)
+		ret = append(ret, getInstanceMigrations(block.Body(), from, to)...)
+	}
+	return ret, nil
+}
+
+// StateScript returns a shell script with the terraform commands to run the state migrations.
+// Migrations not resolved are added as commented out commands so they can be completed manually.
+func StateScript(migrations []StateMigration) []byte {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString("# " + commentGeneratedBy + "\n")
+	sb.WriteString("# " + commentStateScript + "\n")
+	sb.WriteString("set -eu\n")
+	for _, m := range migrations {
+		sb.WriteString("\n")
+		linePrefix := ""
+		if !m.Resolved {
+			sb.WriteString("# " + m.Note + "\n")
+			linePrefix = "# "
+		}
+		fmt.Fprintf(&sb, "%sterraform import %s %s\n", linePrefix, shellQuote(m.To), shellQuote(m.ImportID))
+		fmt.Fprintf(&sb, "%sterraform state rm %s\n", linePrefix, shellQuote(m.From))
+	}
+	return []byte(sb.String())
+}
+
+func getInstanceMigrations(resourceb *hclwrite.Body, from, to string) []StateMigration {
+	keys, note := getInstanceKeys(resourceb)
+	if note != "" {
+		note = fmt.Sprintf("%s: %s", from, note)
+		return []StateMigration{{From: from, To: to, ImportID: getImportIDTemplate(resourceb), Note: note}}
+	}
+	var ret []StateMigration
+	for _, key := range keys {
+		m := StateMigration{From: from, To: to}
+		if key != nil {
+			m.From += formatInstanceKey(key.key)
+			m.To += formatInstanceKey(key.key)
+		}
+		importID, err := evalImportID(resourceb, key)
+		if err == nil {
+			m.ImportID = importID
+			m.Resolved = true
+		} else {
+			m.ImportID = getImportIDTemplate(resourceb)
+			m.Note = fmt.Sprintf("%s: "+commentStateNotResolved, m.From, err)
+		}
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+// getInstanceKeys returns the instance keys of a resource, a nil key is returned if count or for_each are not used.
+// A note is returned if the instance keys can't be evaluated statically.
+func getInstanceKeys(resourceb *hclwrite.Body) (keys []*instanceKey, note string) {
+	if countAttr := resourceb.GetAttribute(nCount); countAttr != nil {
+		count, err := internalhcl.GetAttrInt(countAttr, nCount)
+		if err != nil {
+			return nil, fmt.Sprintf(commentStateKeysNotResolved, nCount)
+		}
+		for i := range count {
+			keys = append(keys, &instanceKey{key: cty.NumberIntVal(int64(i))})
+		}
+		return keys, ""
+	}
+	forEachAttr := resourceb.GetAttribute(nForEach)
+	if forEachAttr == nil {
+		return []*instanceKey{nil}, ""
+	}
+	val, err := evalAttr(forEachAttr, nil)
+	if err != nil || !val.IsWhollyKnown() || val.IsNull() {
+		return nil, fmt.Sprintf(commentStateKeysNotResolved, nForEach)
+	}
+	switch {
+	case val.Type().IsMapType() || val.Type().IsObjectType():
+		for k, v := range val.AsValueMap() {
+			keys = append(keys, &instanceKey{key: cty.StringVal(k), value: v})
+		}
+	case val.Type().IsSetType() || val.Type().IsTupleType() || val.Type().IsListType():
+		for _, v := range val.AsValueSlice() {
+			if !v.Type().Equals(cty.String) {
+				return nil, fmt.Sprintf(commentStateKeysNotResolved, nForEach)
+			}
+			keys = append(keys, &instanceKey{key: v, value: v})
+		}
+	default:
+		return nil, fmt.Sprintf(commentStateKeysNotResolved, nForEach)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key.AsString() < keys[j].key.AsString() })
+	return keys, ""
+}
+
+// evalImportID evaluates the import id of an instance with format <project_id>-<name>.
+func evalImportID(resourceb *hclwrite.Body, key *instanceKey) (string, error) {
+	var parts []string
+	for _, name := range []string{nProjectID, nName} {
+		attr := resourceb.GetAttribute(name)
+		if attr == nil {
+			return "", fmt.Errorf("attribute %s not found", name)
+		}
+		val, err := evalAttr(attr, getEvalContext(key))
+		if err != nil || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+			return "", fmt.Errorf("attribute %s can't be evaluated statically", name)
+		}
+		parts = append(parts, val.AsString())
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// getImportIDTemplate returns the import id as an HCL string template so it can be completed manually.
+func getImportIDTemplate(resourceb *hclwrite.Body) string {
+	tokens := getImportID(resourceb)
+	if tokens == nil {
+		return ""
+	}
+	template := string(tokens.Bytes())
+	return template[1 : len(template)-1] // remove enclosing quotes
+}
+
+func getEvalContext(key *instanceKey) *hcl.EvalContext {
+	if key == nil {
+		return nil
+	}
+	if key.key.Type().Equals(cty.Number) {
+		return &hcl.EvalContext{Variables: map[string]cty.Value{
+			nCount: cty.ObjectVal(map[string]cty.Value{"index": key.key}),
+		}}
+	}
+	return &hcl.EvalContext{Variables: map[string]cty.Value{
+		nEach: cty.ObjectVal(map[string]cty.Value{nKey: key.key, nValue: key.value}),
+	}}
+}
+
+func evalAttr(attr *hclwrite.Attribute, ctx *hcl.EvalContext) (cty.Value, error) {
+	expr, err := internalhcl.ParseAttrExpr(attr)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("failed to evaluate expression: %s", diags.Error())
+	}
+	return val, nil
+}
+
+// formatInstanceKey returns the instance key in a resource address, e.g. [0] or ["key"].
+func formatInstanceKey(key cty.Value) string {
+	if key.Type().Equals(cty.Number) {
+		num, _ := key.AsBigFloat().Int64()
+		return fmt.Sprintf("[%d]", num)
+	}
+	return "[" + strconv.Quote(key.AsString()) + "]"
+}
+
+// shellQuote quotes a string so it can be used as a single shell argument.
+func shellQuote(str string) string {
+	return "'" + strings.ReplaceAll(str, "'", `'\''`) + "'"
+}
@@ -0,0 +1,42 @@
+package convert_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/sebdah/goldie/v2"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterStateMigrations(t *testing.T) {
+	const (
+		inSuffix   = ".in.tf"
+		modulePath = `module.db["main"]`
+	)
+	root := filepath.Join("testdata", "statescript")
+	fs := afero.NewOsFs()
+	inputFiles, err := afero.Glob(fs, filepath.Join(root, "*"+inSuffix))
+	require.NoError(t, err)
+	assert.NotEmpty(t, inputFiles)
+	for _, inputFile := range inputFiles {
+		testName := strings.TrimSuffix(filepath.Base(inputFile), inSuffix)
+		t.Run(testName, func(t *testing.T) {
+			inConfig, err := afero.ReadFile(fs, inputFile)
+			require.NoError(t, err)
+			migrations, err := convert.ClusterStateMigrations(inConfig, modulePath,
+				convert.Options{ConvertFlex: strings.Contains(testName, "convertFlex")})
+			require.NoError(t, err)
+			manifest, err := json.MarshalIndent(migrations, "", "  ")
+			require.NoError(t, err)
+			goldie.New(t, goldie.WithFixtureDir(root), goldie.WithNameSuffix(".out.sh")).
+				Assert(t, testName, convert.StateScript(migrations))
+			goldie.New(t, goldie.WithFixtureDir(root), goldie.WithNameSuffix(".out.json")).
+				Assert(t, testName, manifest)
+		})
+	}
+}
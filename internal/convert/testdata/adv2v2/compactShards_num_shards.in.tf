@@ -0,0 +1,48 @@
+resource "mongodbatlas_advanced_cluster" "multiple_numerical_num_shards" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+  disk_size_gb = 80
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 10
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        node_count    = 3
+        instance_size = "M10"
+      }
+    }
+  }
+  replication_specs {
+    zone_name = "Zone 2"
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_WEST_2"
+      priority      = 7
+      electable_specs {
+        node_count    = 3
+        instance_size = "M10"
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_advanced_cluster" "single_shard_not_compacted" {
+  project_id   = var.project_id
+  name         = "rs"
+  cluster_type = "REPLICASET"
+  replication_specs {
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        node_count    = 3
+        instance_size = "M10"
+      }
+    }
+  }
+}
@@ -0,0 +1,34 @@
+data "mongodbatlas_advanced_cluster" "cluster" {
+  project_id                     = var.project_id
+  name                           = "cluster"
+  use_replication_spec_per_shard = true
+}
+
+data "mongodbatlas_advanced_clusters" "clusters" {
+  project_id                     = var.project_id
+  use_replication_spec_per_shard = true
+}
+
+data "mongodbatlas_advanced_cluster" "no_args" {
+  project_id = var.project_id
+  name       = "no_args"
+}
+
+data "mongodbatlas_cluster" "unchanged" {
+  project_id = var.project_id
+  name       = "unchanged"
+}
+
+locals {
+  instance_size    = data.mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].electable_specs[0].instance_size
+  node_count       = data.mongodbatlas_advanced_cluster.cluster.replication_specs.0.region_configs.0.electable_specs.0.node_count
+  compute_max_size = data.mongodbatlas_advanced_clusters.clusters.results[0].replication_specs[0].region_configs[0].auto_scaling[0].compute_max_instance_size
+  javascript       = data.mongodbatlas_advanced_cluster.cluster.advanced_configuration[0].javascript_enabled
+  srv              = mongodbatlas_advanced_cluster.resource["key"].connection_strings[0].standard_srv
+  all_sizes        = data.mongodbatlas_advanced_cluster.cluster.replication_specs[*].region_configs[0].read_only_specs[0].instance_size
+  unchanged        = data.mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].region_name
+}
+
+output "electable_node_counts" {
+  value = [for spec in data.mongodbatlas_advanced_cluster.cluster.replication_specs : spec.region_configs[0].electable_specs[0].node_count]
+}
@@ -0,0 +1,38 @@
+data "mongodbatlas_advanced_cluster" "cluster" {
+  project_id = var.project_id
+  name       = "cluster"
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+data "mongodbatlas_advanced_clusters" "clusters" {
+  project_id = var.project_id
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+data "mongodbatlas_advanced_cluster" "no_args" {
+  project_id = var.project_id
+  name       = "no_args"
+}
+
+data "mongodbatlas_cluster" "unchanged" {
+  project_id = var.project_id
+  name       = "unchanged"
+}
+
+locals {
+  instance_size    = data.mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].electable_specs.instance_size
+  node_count       = data.mongodbatlas_advanced_cluster.cluster.replication_specs.0.region_configs.0.electable_specs.node_count
+  compute_max_size = data.mongodbatlas_advanced_clusters.clusters.results[0].replication_specs[0].region_configs[0].auto_scaling.compute_max_instance_size
+  javascript       = data.mongodbatlas_advanced_cluster.cluster.advanced_configuration.javascript_enabled
+  srv              = mongodbatlas_advanced_cluster.resource["key"].connection_strings.standard_srv
+  all_sizes        = data.mongodbatlas_advanced_cluster.cluster.replication_specs[*].region_configs[0].read_only_specs.instance_size
+  unchanged        = data.mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].region_name
+  # Updated by atlas-cli-plugin-terraform, references to nested attributes of mongodbatlas_advanced_cluster were changed to the Provider 2.0.0 schema, please review the changes.
+}
+
+output "electable_node_counts" {
+  value = [for spec in data.mongodbatlas_advanced_cluster.cluster.replication_specs : spec.region_configs[0].electable_specs[0].node_count]
+  # for expressions over mongodbatlas_advanced_cluster can't be updated to the Provider 2.0.0 schema, remove the [0] index of nested attributes like electable_specs in their iteration variables.
+}
@@ -0,0 +1,51 @@
+resource "mongodbatlas_advanced_cluster" "dynamic_replication_specs" {
+  project_id   = var.project_id
+  name         = var.cluster_name
+  cluster_type = "GEOSHARDED"
+
+  dynamic "replication_specs" {
+    for_each = var.replication_specs
+    content {
+      num_shards = replication_specs.value.num_shards
+      zone_name  = replication_specs.value.zone_name
+      dynamic "region_configs" {
+        for_each = replication_specs.value.region_configs
+        content {
+          priority      = region_configs.value.priority
+          provider_name = region_configs.value.provider_name
+          region_name   = region_configs.value.region_name
+          electable_specs {
+            instance_size = region_configs.value.instance_size
+            node_count    = region_configs.value.electable_node_count
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_advanced_cluster" "each_value_not_extracted" {
+  for_each     = var.clusters
+  project_id   = var.project_id
+  name         = each.key
+  cluster_type = "GEOSHARDED"
+
+  dynamic "replication_specs" {
+    for_each = each.value.replication_specs
+    content {
+      num_shards = replication_specs.value.num_shards
+      dynamic "region_configs" {
+        for_each = replication_specs.value.region_configs
+        content {
+          priority      = region_configs.value.priority
+          provider_name = region_configs.value.provider_name
+          region_name   = region_configs.value.region_name
+          electable_specs {
+            instance_size = region_configs.value.instance_size
+            node_count    = region_configs.value.electable_node_count
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,57 @@
+resource "mongodbatlas_advanced_cluster" "dynamic_replication_specs" {
+  project_id   = var.project_id
+  name         = var.cluster_name
+  cluster_type = "GEOSHARDED"
+
+  replication_specs = local.dynamic_replication_specs_replication_specs
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+locals {
+  dynamic_replication_specs_replication_specs = flatten([
+    for spec in var.replication_specs : [
+      for i in range(spec.num_shards) : {
+        zone_name = spec.zone_name
+        region_configs = [
+          for region in spec.region_configs : {
+            priority      = region.priority
+            provider_name = region.provider_name
+            region_name   = region.region_name
+            electable_specs = {
+              instance_size = region.instance_size
+              node_count    = region.electable_node_count
+            }
+          }
+        ]
+      }
+    ]
+  ])
+}
+
+resource "mongodbatlas_advanced_cluster" "each_value_not_extracted" {
+  for_each     = var.clusters
+  project_id   = var.project_id
+  name         = each.key
+  cluster_type = "GEOSHARDED"
+
+  replication_specs = flatten([
+    for spec in each.value.replication_specs : [
+      for i in range(spec.num_shards) : {
+        region_configs = [
+          for region in spec.region_configs : {
+            priority      = region.priority
+            provider_name = region.provider_name
+            region_name   = region.region_name
+            electable_specs = {
+              instance_size = region.instance_size
+              node_count    = region.electable_node_count
+            }
+          }
+        ]
+      }
+    ]
+  ])
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
@@ -0,0 +1,22 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "SHARDED"
+
+  replication_specs {
+    num_shards = 2
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        instance_size = "M30"
+        node_count    = 3
+      }
+      analytics_specs {
+        instance_size = "M30"
+        node_count    = 1
+      }
+    }
+  }
+}
@@ -0,0 +1,62 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "SHARDED"
+
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 3
+          }
+          analytics_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+        }
+      ]
+    },
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 3
+          }
+          analytics_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+        }
+      ]
+    }
+  ]
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+# Check blocks
+# Note: Terraform 1.5 or later is required, they warn if the topology of the converted clusters changes.
+
+check "cluster_topology" {
+  assert {
+    condition     = length(mongodbatlas_advanced_cluster.cluster.replication_specs) == 2
+    error_message = "mongodbatlas_advanced_cluster.cluster number of shards must be 2"
+  }
+  assert {
+    condition     = toset(flatten([for spec in mongodbatlas_advanced_cluster.cluster.replication_specs : spec.region_configs[*].region_name])) == toset(["US_EAST_1"])
+    error_message = "mongodbatlas_advanced_cluster.cluster must be deployed in regions US_EAST_1"
+  }
+  assert {
+    condition     = sum(flatten([for spec in mongodbatlas_advanced_cluster.cluster.replication_specs : [for config in spec.region_configs : try(config.electable_specs.node_count, 0)]])) == 6
+    error_message = "mongodbatlas_advanced_cluster.cluster number of electable nodes must be 6"
+  }
+}
@@ -0,0 +1,45 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id     = var.project_id
+  name           = "cluster"
+  cluster_type   = "SHARDED"
+  backup_enabled = true
+  disk_size_gb   = 100
+  replication_specs {
+    num_shards = 2
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        instance_size = "M30"
+        node_count    = 3
+      }
+      auto_scaling {
+        compute_enabled = true
+      }
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [
+      disk_size_gb,
+      replication_specs[0].region_configs[0].electable_specs[0].instance_size,
+      replication_specs[0].num_shards,
+      replication_specs[1].zone_name,
+    ]
+    postcondition {
+      condition     = self.replication_specs[0].region_configs[0].auto_scaling[0].compute_enabled
+      error_message = "Auto-scaling must be enabled in ${self.replication_specs[0].id}."
+    }
+  }
+}
+
+resource "null_resource" "trigger" {
+  lifecycle {
+    replace_triggered_by = [mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].electable_specs[0]]
+    precondition {
+      condition     = mongodbatlas_advanced_cluster.cluster.disk_size_gb >= 100
+      error_message = "Disk size must be at least 100 GB."
+    }
+  }
+}
@@ -0,0 +1,69 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id     = var.project_id
+  name           = "cluster"
+  cluster_type   = "SHARDED"
+  backup_enabled = true
+
+  lifecycle {
+    ignore_changes = [
+      replication_specs[0].region_configs[0].electable_specs.disk_size_gb,
+      replication_specs[1].region_configs[0].electable_specs.disk_size_gb,
+      replication_specs[0].region_configs[0].electable_specs.instance_size
+    ]
+    postcondition {
+      condition     = self.replication_specs[0].region_configs[0].auto_scaling.compute_enabled
+      error_message = "Auto-scaling must be enabled in ${self.replication_specs[0].zone_id}."
+    }
+    # replication_specs[0].num_shards was removed from ignore_changes as it doesn't have an equivalent in mongodbatlas_advanced_cluster 2.0.0, add the new paths manually if needed.
+    # replication_specs[1].zone_name was removed from ignore_changes as it doesn't have an equivalent in mongodbatlas_advanced_cluster 2.0.0, add the new paths manually if needed.
+  }
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 3
+            disk_size_gb  = 100
+          }
+          auto_scaling = {
+            compute_enabled = true
+          }
+        }
+      ]
+    },
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 3
+            disk_size_gb  = 100
+          }
+          auto_scaling = {
+            compute_enabled = true
+          }
+        }
+      ]
+    }
+  ]
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+resource "null_resource" "trigger" {
+  lifecycle {
+    replace_triggered_by = [mongodbatlas_advanced_cluster.cluster.replication_specs[0].region_configs[0].electable_specs]
+    precondition {
+      condition     = mongodbatlas_advanced_cluster.cluster.disk_size_gb >= 100
+      error_message = "Disk size must be at least 100 GB."
+    }
+    # mongodbatlas_advanced_cluster.cluster.disk_size_gb in condition can't be converted to mongodbatlas_advanced_cluster 2.0.0, please review it.
+  }
+}
@@ -0,0 +1,44 @@
+resource "mongodbatlas_advanced_cluster" "multi_cloud" {
+  project_id   = var.project_id
+  name         = "cluster-multi-cloud"
+  cluster_type = "REPLICASET"
+
+  replication_specs {
+    region_configs {
+      provider_name = "GCP"
+      region_name   = "europe-west4"
+      priority      = 7
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 3
+      }
+    }
+    region_configs {
+      provider_name = "AZURE"
+      region_name   = "northeurope"
+      priority      = 6
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 2
+      }
+    }
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "EU_WEST_1"
+      priority      = 0
+      read_only_specs {
+        instance_size = "M10"
+        node_count    = 1
+      }
+    }
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "EUROPE_NORTH" # Azure region
+      priority      = 0
+      read_only_specs {
+        instance_size = "M10"
+        node_count    = 1
+      }
+    }
+  }
+}
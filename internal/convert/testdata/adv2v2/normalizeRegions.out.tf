@@ -0,0 +1,53 @@
+resource "mongodbatlas_advanced_cluster" "multi_cloud" {
+  project_id   = var.project_id
+  name         = "cluster-multi-cloud"
+  cluster_type = "REPLICASET"
+
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "GCP"
+          region_name   = "EUROPE_WEST_4"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        },
+        {
+          provider_name = "AZURE"
+          region_name   = "EUROPE_NORTH"
+          priority      = 6
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 2
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 0
+          read_only_specs = {
+            instance_size = "M10"
+            node_count    = 1
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EUROPE_NORTH" # Azure region
+          priority      = 0
+          read_only_specs = {
+            instance_size = "M10"
+            node_count    = 1
+          }
+        }
+      ]
+    }
+  ]
+  # region_name europe-west4 was changed to the Atlas region name EUROPE_WEST_4.
+  # region_name northeurope was changed to the Atlas region name EUROPE_NORTH.
+  # region_name EUROPE_NORTH is not a valid AWS region in Atlas, it's a region of AZURE.
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
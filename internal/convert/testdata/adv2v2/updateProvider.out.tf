@@ -0,0 +1,44 @@
+terraform {
+  required_version = ">= 1.8"
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 2.0"
+    }
+  }
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+terraform {
+  required_providers {
+    atlas = {
+      source  = "mongodb/mongodbatlas"
+      version = var.provider_version
+    }
+  }
+  # mongodbatlas version constraint was not updated to Provider 2.X.X because it can't be evaluated statically, please update it manually.
+}
+
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
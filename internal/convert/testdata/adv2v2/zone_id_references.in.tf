@@ -0,0 +1,39 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  for_each     = var.clusters
+  project_id   = var.project_id
+  name         = each.key
+  cluster_type = "REPLICASET"
+  replication_specs {
+    region_configs {
+      priority      = 7
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      electable_specs {
+        node_count    = 3
+        instance_size = "M10"
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "schedule" {
+  for_each     = var.clusters
+  project_id   = mongodbatlas_advanced_cluster.cluster[each.key].project_id
+  cluster_name = mongodbatlas_advanced_cluster.cluster[each.key].name
+
+  copy_settings {
+    cloud_provider      = "AWS"
+    frequencies         = ["HOURLY", "DAILY"]
+    region_name         = "US_WEST_2"
+    replication_spec_id = mongodbatlas_advanced_cluster.cluster[each.key].replication_specs[0].id
+    should_copy_oplogs  = false
+  }
+}
+
+output "data_source_replication_spec_id" {
+  value = data.mongodbatlas_advanced_cluster.other.replication_specs.0.id
+}
+
+output "unchanged" {
+  value = mongodbatlas_advanced_cluster.cluster["key"].replication_specs[0].zone_name
+}
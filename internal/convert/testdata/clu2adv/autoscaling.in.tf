@@ -26,8 +26,8 @@ resource "mongodbatlas_cluster" "autoscaling" {
   provider_instance_size_name                     = "M20"
 
   lifecycle { // To simulate if there a new instance size name to avoid scale cluster down to original value
-    # Note that provider_instance_size_name won't exist in advanced_cluster so it's an error to refer to it,
-    # but plugin doesn't help here.
+    # Note that provider_instance_size_name won't exist in advanced_cluster,
+    # it's changed to instance_size in all region configs.
     ignore_changes = [provider_instance_size_name]
   }
 }
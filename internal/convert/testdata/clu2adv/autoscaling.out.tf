@@ -6,9 +6,9 @@ resource "mongodbatlas_advanced_cluster" "autoscaling" {
 
 
   lifecycle { // To simulate if there a new instance size name to avoid scale cluster down to original value
-    # Note that provider_instance_size_name won't exist in advanced_cluster so it's an error to refer to it,
-    # but plugin doesn't help here.
-    ignore_changes = [provider_instance_size_name]
+    # Note that provider_instance_size_name won't exist in advanced_cluster,
+    # it's changed to instance_size in all region configs.
+    ignore_changes = [replication_specs[0].region_configs[0].electable_specs.instance_size]
   }
   backup_enabled = true
   replication_specs = [
@@ -0,0 +1,69 @@
+resource "mongodbatlas_cluster" "multiple_numerical_num_shards" {
+  project_id                  = var.project_id
+  name                        = "geo"
+  disk_size_gb                = 80
+  cloud_backup                = false
+  cluster_type                = "GEOSHARDED"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 10
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+  replication_specs {
+    zone_name  = "Zone 2"
+    num_shards = 1
+    regions_config {
+      region_name     = "US_WEST_2"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "mix_variable_numerical_num_shards" {
+  project_id                  = var.project_id
+  name                        = "geo"
+  cluster_type                = "GEOSHARDED"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 3
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+  replication_specs {
+    zone_name  = "Zone 2"
+    num_shards = var.num_shards
+    regions_config {
+      region_name     = "US_WEST_2"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "single_shard_not_compacted" {
+  project_id                  = var.project_id
+  name                        = "rs"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
@@ -0,0 +1,112 @@
+resource "mongodbatlas_advanced_cluster" "multiple_numerical_num_shards" {
+  project_id     = var.project_id
+  name           = "geo"
+  cluster_type   = "GEOSHARDED"
+  backup_enabled = false
+  replication_specs = concat(
+    [
+      for i in range(10) : {
+        zone_name = "Zone 1"
+        region_configs = [
+          {
+            provider_name = "AWS"
+            region_name   = "US_EAST_1"
+            priority      = 7
+            electable_specs = {
+              node_count    = 3
+              instance_size = "M10"
+              disk_size_gb  = 80
+            }
+          }
+        ]
+      }
+    ],
+    [
+      {
+        zone_name = "Zone 2"
+        region_configs = [
+          {
+            provider_name = "AWS"
+            region_name   = "US_WEST_2"
+            priority      = 7
+            electable_specs = {
+              node_count    = 3
+              instance_size = "M10"
+              disk_size_gb  = 80
+            }
+          }
+        ]
+      }
+    ]
+  )
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "mix_variable_numerical_num_shards" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+  replication_specs = concat(
+    [
+      for i in range(3) : {
+        zone_name = "Zone 1"
+        region_configs = [
+          {
+            provider_name = "AWS"
+            region_name   = "US_EAST_1"
+            priority      = 7
+            electable_specs = {
+              node_count    = 3
+              instance_size = "M10"
+            }
+          }
+        ]
+      }
+    ],
+    [
+      for i in range(var.num_shards) : {
+        zone_name = "Zone 2"
+        region_configs = [
+          {
+            provider_name = "AWS"
+            region_name   = "US_WEST_2"
+            priority      = 7
+            electable_specs = {
+              node_count    = 3
+              instance_size = "M10"
+            }
+          }
+        ]
+      }
+    ]
+  )
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "single_shard_not_compacted" {
+  project_id   = var.project_id
+  name         = "rs"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
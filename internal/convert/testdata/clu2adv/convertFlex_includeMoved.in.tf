@@ -0,0 +1,63 @@
+resource "mongodbatlas_cluster" "m2" {
+  project_id                     = var.project_id
+  name                           = "m2"
+  provider_name                  = "TENANT"
+  backing_provider_name          = "AWS"
+  provider_region_name           = "US_EAST_1"
+  provider_instance_size_name    = "M2"
+  termination_protection_enabled = true
+  tags {
+    key   = "environment"
+    value = "dev"
+  }
+  tags {
+    key   = "team"
+    value = var.team
+  }
+}
+
+resource "mongodbatlas_cluster" "m5" {
+  for_each                    = var.clusters
+  project_id                  = var.project_id
+  name                        = each.key
+  provider_name               = "TENANT"
+  backing_provider_name       = "AZURE"
+  provider_region_name        = each.value.region
+  provider_instance_size_name = "M5"
+  mongo_db_major_version      = "7.0"
+  disk_size_gb                = 5
+  dynamic "tags" {
+    for_each = var.tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+  labels {
+    key   = "label"
+    value = "value"
+  }
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+resource "mongodbatlas_cluster" "m0" {
+  # free clusters are still converted to advanced clusters
+  project_id                  = var.project_id
+  name                        = "m0"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M0"
+}
+
+resource "mongodbatlas_cluster" "variable_size" {
+  # instance size must be known to convert to a flex cluster
+  project_id                  = var.project_id
+  name                        = "variable-size"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = var.instance_size
+}
@@ -0,0 +1,109 @@
+resource "mongodbatlas_flex_cluster" "m2" {
+  project_id                     = var.project_id
+  name                           = "m2"
+  termination_protection_enabled = true
+  provider_settings = {
+    backing_provider_name = "AWS"
+    region_name           = "US_EAST_1"
+  }
+  tags = {
+    environment = "dev"
+    team        = var.team
+  }
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_flex_cluster" "m5" {
+  for_each   = var.clusters
+  project_id = var.project_id
+  name       = each.key
+  provider_settings = {
+    backing_provider_name = "AZURE"
+    region_name           = each.value.region
+  }
+  tags = var.tags
+
+  lifecycle {
+    prevent_destroy = true
+  }
+
+  # Attributes not supported in mongodbatlas_flex_cluster were removed: disk_size_gb, labels, mongo_db_major_version.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "m0" {
+  # free clusters are still converted to advanced clusters
+  project_id   = var.project_id
+  name         = "m0"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = "US_EAST_1"
+          provider_name         = "TENANT"
+          backing_provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "variable_size" {
+  # instance size must be known to convert to a flex cluster
+  project_id   = var.project_id
+  name         = "variable-size"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = "US_EAST_1"
+          provider_name         = "TENANT"
+          backing_provider_name = "AWS"
+          electable_specs = {
+            instance_size = var.instance_size
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Moved blocks
+# Note: Remember to remove or comment out the old cluster definitions.
+
+moved {
+  from = mongodbatlas_cluster.m2
+  to   = mongodbatlas_flex_cluster.m2
+}
+
+moved {
+  from = mongodbatlas_cluster.m5
+  to   = mongodbatlas_flex_cluster.m5
+}
+
+moved {
+  from = mongodbatlas_cluster.m0
+  to   = mongodbatlas_advanced_cluster.m0
+}
+
+moved {
+  from = mongodbatlas_cluster.variable_size
+  to   = mongodbatlas_advanced_cluster.variable_size
+}
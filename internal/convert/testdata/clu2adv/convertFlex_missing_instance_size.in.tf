@@ -0,0 +1,8 @@
+resource "mongodbatlas_cluster" "shared" {
+  project_id            = var.project_id
+  name                  = var.cluster_name
+  provider_name         = "TENANT"
+  backing_provider_name = "AWS"
+  provider_region_name  = var.region
+  # missing provider_instance_size_name
+}
@@ -0,0 +1,65 @@
+resource "mongodbatlas_cluster" "dynamic_replication_specs" {
+  project_id                  = var.project_id
+  name                        = var.cluster_name
+  cluster_type                = "GEOSHARDED"
+  provider_instance_size_name = var.instance_size
+  provider_name               = var.provider_name
+
+  dynamic "replication_specs" {
+    for_each = var.replication_specs
+    content {
+      num_shards = replication_specs.value.num_shards
+      zone_name  = replication_specs.value.zone_name
+
+      dynamic "regions_config" {
+        for_each = replication_specs.value.regions_config
+        content {
+          electable_nodes = regions_config.value.electable_nodes
+          priority        = regions_config.value.priority
+          read_only_nodes = regions_config.value.read_only_nodes
+          region_name     = regions_config.value.region_name
+        }
+      }
+    }
+  }
+}
+
+resource "another_resource" "res1" {
+  name = "name1"
+}
+
+resource "mongodbatlas_cluster" "count_index_not_extracted" {
+  count                       = length(var.clusters)
+  project_id                  = var.project_id
+  name                        = var.clusters[count.index].name
+  cluster_type                = "REPLICASET"
+  provider_instance_size_name = "M10"
+  provider_name               = "AWS"
+  replication_specs {
+    num_shards = var.clusters[count.index].num_shards
+    dynamic "regions_config" {
+      for_each = var.clusters[count.index].regions_config
+      content {
+        electable_nodes = regions_config.value.electable_nodes
+        priority        = regions_config.value.priority
+        region_name     = regions_config.value.region_name
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "static_not_extracted" {
+  project_id                  = var.project_id
+  name                        = "static"
+  cluster_type                = "REPLICASET"
+  provider_instance_size_name = "M10"
+  provider_name               = "AWS"
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
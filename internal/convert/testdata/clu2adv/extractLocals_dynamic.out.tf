@@ -0,0 +1,94 @@
+resource "mongodbatlas_advanced_cluster" "dynamic_replication_specs" {
+  project_id   = var.project_id
+  name         = var.cluster_name
+  cluster_type = "GEOSHARDED"
+
+  replication_specs = local.dynamic_replication_specs_replication_specs
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+locals {
+  dynamic_replication_specs_replication_specs = flatten([
+    for spec in var.replication_specs : [
+      for i in range(spec.num_shards) : {
+        zone_name = spec.zone_name
+        region_configs = flatten([
+          # Regions must be sorted by priority in descending order.
+          for priority in range(7, 0, -1) : [
+            for region in spec.regions_config : {
+              provider_name = var.provider_name
+              region_name   = region.region_name
+              priority      = region.priority
+              electable_specs = region.electable_nodes == 0 ? null : {
+                node_count    = region.electable_nodes
+                instance_size = var.instance_size
+              }
+              read_only_specs = region.read_only_nodes == 0 ? null : {
+                node_count    = region.read_only_nodes
+                instance_size = var.instance_size
+              }
+            } if priority == region.priority
+          ]
+        ])
+      }
+    ]
+  ])
+}
+
+resource "another_resource" "res1" {
+  name = "name1"
+}
+
+resource "mongodbatlas_advanced_cluster" "count_index_not_extracted" {
+  count        = length(var.clusters)
+  project_id   = var.project_id
+  name         = var.clusters[count.index].name
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    for i in range(var.clusters[count.index].num_shards) : {
+      region_configs = flatten([
+        # Regions must be sorted by priority in descending order.
+        for priority in range(7, 0, -1) : [
+          for region in var.clusters[count.index].regions_config : {
+            provider_name = "AWS"
+            region_name   = region.region_name
+            priority      = region.priority
+            electable_specs = region.electable_nodes == 0 ? null : {
+              node_count    = region.electable_nodes
+              instance_size = "M10"
+            }
+          } if priority == region.priority
+        ]
+      ])
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "static_not_extracted" {
+  project_id   = var.project_id
+  name         = "static"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
@@ -0,0 +1,59 @@
+resource "mongodbatlas_cluster" "geo" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+
+  provider_name               = "AWS"
+  provider_instance_size_name = "M30"
+
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 2
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+  replication_specs {
+    zone_name  = "Zone 2"
+    num_shards = 1
+    regions_config {
+      region_name     = "EU_WEST_1"
+      electable_nodes = 2
+      priority        = 7
+    }
+    regions_config {
+      region_name     = "EU_CENTRAL_1"
+      electable_nodes = 1
+      read_only_nodes = 1
+      priority        = 6
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "free" {
+  project_id                  = var.project_id
+  name                        = "free"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M0"
+}
+
+resource "mongodbatlas_cluster" "variable_region" {
+  project_id                  = var.project_id
+  name                        = "variable-region"
+  provider_name               = "AWS"
+  provider_region_name        = var.region
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "count" {
+  count                       = 2
+  project_id                  = var.project_id
+  name                        = "count-${count.index}"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
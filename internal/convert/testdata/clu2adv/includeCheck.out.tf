@@ -0,0 +1,173 @@
+resource "mongodbatlas_advanced_cluster" "geo" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+
+
+  replication_specs = [
+    {
+      zone_name = "Zone 1"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+          }
+        }
+      ]
+    },
+    {
+      zone_name = "Zone 1"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+          }
+        }
+      ]
+    },
+    {
+      zone_name = "Zone 2"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 2
+            instance_size = "M30"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EU_CENTRAL_1"
+          priority      = 6
+          electable_specs = {
+            node_count    = 1
+            instance_size = "M30"
+          }
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M30"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "free" {
+  project_id   = var.project_id
+  name         = "free"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = "US_EAST_1"
+          provider_name         = "TENANT"
+          backing_provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "variable_region" {
+  project_id   = var.project_id
+  name         = "variable-region"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = var.region
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "count" {
+  count        = 2
+  project_id   = var.project_id
+  name         = "count-${count.index}"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Check blocks
+# Note: Terraform 1.5 or later is required, they warn if the topology of the converted clusters changes.
+
+check "geo_topology" {
+  assert {
+    condition     = length(mongodbatlas_advanced_cluster.geo.replication_specs) == 3
+    error_message = "mongodbatlas_advanced_cluster.geo number of shards must be 3"
+  }
+  assert {
+    condition     = toset(flatten([for spec in mongodbatlas_advanced_cluster.geo.replication_specs : spec.region_configs[*].region_name])) == toset(["EU_CENTRAL_1", "EU_WEST_1", "US_EAST_1"])
+    error_message = "mongodbatlas_advanced_cluster.geo must be deployed in regions EU_CENTRAL_1, EU_WEST_1, US_EAST_1"
+  }
+  assert {
+    condition     = sum(flatten([for spec in mongodbatlas_advanced_cluster.geo.replication_specs : [for config in spec.region_configs : try(config.electable_specs.node_count, 0)]])) == 9
+    error_message = "mongodbatlas_advanced_cluster.geo number of electable nodes must be 9"
+  }
+}
+
+check "free_topology" {
+  assert {
+    condition     = length(mongodbatlas_advanced_cluster.free.replication_specs) == 1
+    error_message = "mongodbatlas_advanced_cluster.free number of shards must be 1"
+  }
+  assert {
+    condition     = toset(flatten([for spec in mongodbatlas_advanced_cluster.free.replication_specs : spec.region_configs[*].region_name])) == toset(["US_EAST_1"])
+    error_message = "mongodbatlas_advanced_cluster.free must be deployed in regions US_EAST_1"
+  }
+}
+
+# Check block not generated for variable_region: attribute provider_region_name can't be evaluated statically.
+
+# Check block not generated for count: resources with count are not supported.
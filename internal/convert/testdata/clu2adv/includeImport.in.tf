@@ -0,0 +1,33 @@
+resource "mongodbatlas_cluster" "cluster" {
+  project_id                  = var.project_id
+  name                        = var.cluster_name
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "for_each" {
+  for_each                    = var.clusters
+  project_id                  = local.project_id
+  name                        = each.key
+  provider_name               = "AWS"
+  provider_region_name        = each.value.region
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "count" {
+  count                       = 2
+  project_id                  = var.project_id
+  name                        = "cluster-${count.index}"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "project_reference" {
+  project_id                  = mongodbatlas_project.this.id
+  name                        = "clu"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
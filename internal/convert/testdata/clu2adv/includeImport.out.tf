@@ -0,0 +1,119 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = var.cluster_name
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "for_each" {
+  for_each     = var.clusters
+  project_id   = local.project_id
+  name         = each.key
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = each.value.region
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "count" {
+  count        = 2
+  project_id   = var.project_id
+  name         = "cluster-${count.index}"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "project_reference" {
+  project_id   = mongodbatlas_project.this.id
+  name         = "clu"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Import blocks
+# Note: Remember to remove the old clusters from the state before applying, e.g. with terraform state rm.
+
+import {
+  to = mongodbatlas_advanced_cluster.cluster
+  id = "${var.project_id}-${var.cluster_name}"
+}
+
+import {
+  for_each = var.clusters
+  to       = mongodbatlas_advanced_cluster.for_each[each.key]
+  id       = "${local.project_id}-${each.key}"
+}
+
+# Import block not generated for count: resources with count need an import block for each instance.
+
+# Import id of project_reference can't be derived statically, it must be known during plan.
+import {
+  to = mongodbatlas_advanced_cluster.project_reference
+  id = "${mongodbatlas_project.this.id}-clu"
+}
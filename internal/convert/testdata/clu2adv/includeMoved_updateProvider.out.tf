@@ -0,0 +1,48 @@
+terraform {
+  required_version = ">= 1.5"
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 2.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+  # required_version allows Terraform versions older than 1.8, which is required by the moved blocks across resource types.
+}
+
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Moved blocks
+# Note: Remember to remove or comment out the old cluster definitions.
+
+moved {
+  from = mongodbatlas_cluster.cluster
+  to   = mongodbatlas_advanced_cluster.cluster
+}
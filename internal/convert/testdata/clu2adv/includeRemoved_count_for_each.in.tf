@@ -0,0 +1,17 @@
+resource "mongodbatlas_cluster" "count" {
+  count                       = var.create ? 1 : 0
+  project_id                  = var.project_id
+  name                        = "cluster-count"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "for_each" {
+  for_each                    = var.clusters
+  project_id                  = var.project_id
+  name                        = each.key
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
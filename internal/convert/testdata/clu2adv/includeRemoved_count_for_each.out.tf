@@ -0,0 +1,68 @@
+resource "mongodbatlas_advanced_cluster" "count" {
+  count        = var.create ? 1 : 0
+  project_id   = var.project_id
+  name         = "cluster-count"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "for_each" {
+  for_each     = var.clusters
+  project_id   = var.project_id
+  name         = each.key
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Removed and import blocks
+# Note: Terraform 1.7 or later is required, the old clusters are removed from the state without being destroyed.
+
+# Import block not generated for count: resources with count need an import block for each instance.
+# Removed block not generated for count as it can't be imported, migrate its state manually or with moved blocks.
+
+removed {
+  from = mongodbatlas_cluster.for_each
+  lifecycle {
+    destroy = false
+  }
+}
+
+import {
+  for_each = var.clusters
+  to       = mongodbatlas_advanced_cluster.for_each[each.key]
+  id       = "${var.project_id}-${each.key}"
+}
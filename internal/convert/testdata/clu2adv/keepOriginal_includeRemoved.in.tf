@@ -0,0 +1,37 @@
+resource "mongodbatlas_cluster" "cluster" {
+  project_id                  = var.project_id
+  name                        = "clu"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "free" {
+  project_id                  = "66d979971ec97b7de1ef8777"
+  name                        = "free-${var.suffix}"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = var.region
+  provider_instance_size_name = "M0"
+}
+
+resource "mongodbatlas_cluster" "no_project" {
+  name                        = var.cluster_name
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+data "mongodbatlas_cluster" "cluster" {
+  project_id = mongodbatlas_cluster.cluster.project_id
+  name       = mongodbatlas_cluster.cluster.name
+}
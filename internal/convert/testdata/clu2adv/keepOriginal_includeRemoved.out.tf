@@ -0,0 +1,145 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "clu"
+  cluster_type = "REPLICASET"
+
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Original definition of mongodbatlas_cluster.cluster, it can be deleted after the migration is complete:
+# resource "mongodbatlas_cluster" "cluster" {
+#   project_id                  = var.project_id
+#   name                        = "clu"
+#   cluster_type                = "REPLICASET"
+#   provider_name               = "AWS"
+#   provider_instance_size_name = "M10"
+#
+#   replication_specs {
+#     num_shards = 1
+#     regions_config {
+#       region_name     = "US_EAST_1"
+#       electable_nodes = 3
+#       priority        = 7
+#     }
+#   }
+# }
+
+resource "mongodbatlas_advanced_cluster" "free" {
+  project_id   = "66d979971ec97b7de1ef8777"
+  name         = "free-${var.suffix}"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = var.region
+          provider_name         = "TENANT"
+          backing_provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Original definition of mongodbatlas_cluster.free, it can be deleted after the migration is complete:
+# resource "mongodbatlas_cluster" "free" {
+#   project_id                  = "66d979971ec97b7de1ef8777"
+#   name                        = "free-${var.suffix}"
+#   provider_name               = "TENANT"
+#   backing_provider_name       = "AWS"
+#   provider_region_name        = var.region
+#   provider_instance_size_name = "M0"
+# }
+
+resource "mongodbatlas_advanced_cluster" "no_project" {
+  name         = var.cluster_name
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_1"
+          provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Original definition of mongodbatlas_cluster.no_project, it can be deleted after the migration is complete:
+# resource "mongodbatlas_cluster" "no_project" {
+#   name                        = var.cluster_name
+#   provider_name               = "AWS"
+#   provider_region_name        = "US_EAST_1"
+#   provider_instance_size_name = "M10"
+# }
+
+data "mongodbatlas_advanced_cluster" "cluster" {
+  project_id = mongodbatlas_cluster.cluster.project_id
+  name       = mongodbatlas_cluster.cluster.name
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Removed and import blocks
+# Note: Terraform 1.7 or later is required, the old clusters are removed from the state without being destroyed.
+
+removed {
+  from = mongodbatlas_cluster.cluster
+  lifecycle {
+    destroy = false
+  }
+}
+
+import {
+  to = mongodbatlas_advanced_cluster.cluster
+  id = "${var.project_id}-clu"
+}
+
+removed {
+  from = mongodbatlas_cluster.free
+  lifecycle {
+    destroy = false
+  }
+}
+
+import {
+  to = mongodbatlas_advanced_cluster.free
+  id = "66d979971ec97b7de1ef8777-free-${var.suffix}"
+}
+
+# Import block not generated for no_project: project_id and name must be set to build the import id.
+# Removed block not generated for no_project as it can't be imported, migrate its state manually or with moved blocks.
@@ -0,0 +1,56 @@
+resource "mongodbatlas_cluster" "mapped" {
+  project_id                  = var.project_id
+  name                        = "mapped"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AZURE"
+  provider_instance_size_name = "M30"
+  provider_region_name        = "US_EAST_2"
+  provider_disk_type_name     = "P6"
+  replication_factor          = 5
+  provider_backup_enabled     = true
+  backup_enabled              = false
+  container_id                = "5d0f1f73cf09a29120e173cf"
+  bi_connector = {
+    enabled         = true
+    read_preference = "secondary"
+  }
+}
+
+resource "mongodbatlas_cluster" "removed" {
+  project_id                  = var.project_id
+  name                        = "removed"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  provider_encrypt_ebs_volume = true
+  replication_factor          = 3
+  cloud_backup                = true
+  provider_backup_enabled     = true
+  bi_connector = {
+    enabled = true
+  }
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+
+  bi_connector_config {
+    enabled         = true
+    read_preference = "secondary"
+  }
+}
+
+resource "mongodbatlas_cluster" "free" {
+  project_id                  = var.project_id
+  name                        = "free"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M0"
+  replication_factor          = 3
+}
@@ -0,0 +1,91 @@
+resource "mongodbatlas_advanced_cluster" "mapped" {
+  project_id   = var.project_id
+  name         = "mapped"
+  cluster_type = "REPLICASET"
+  bi_connector_config = {
+    enabled         = true
+    read_preference = "secondary"
+  }
+  backup_enabled = true
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "US_EAST_2"
+          provider_name = "AZURE"
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 5
+          }
+        }
+      ]
+    }
+  ]
+  # backup_enabled was removed, legacy backup is not supported, use cloud_backup for Cloud Backup.
+  # container_id was removed, it's a computed attribute, use mongodbatlas_network_container to get the container id.
+  # provider_disk_type_name was removed, Azure disk type is derived from disk_size_gb.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "removed" {
+  project_id   = var.project_id
+  name         = "removed"
+  cluster_type = "REPLICASET"
+
+
+  backup_enabled = true
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+  bi_connector_config = {
+    enabled         = true
+    read_preference = "secondary"
+  }
+  # provider_encrypt_ebs_volume was removed, EBS volumes are always encrypted.
+  # replication_factor was removed, number of nodes is set in replication_specs.
+  # provider_backup_enabled was removed, cloud_backup is used instead.
+  # bi_connector was removed, bi_connector_config is used instead.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "free" {
+  project_id   = var.project_id
+  name         = "free"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = "US_EAST_1"
+          provider_name         = "TENANT"
+          backing_provider_name = "AWS"
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+  # replication_factor was removed, number of nodes is set in replication_specs.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
@@ -0,0 +1,86 @@
+resource "mongodbatlas_cluster" "geo" {
+  project_id                   = var.project_id
+  name                         = "geo"
+  cluster_type                 = "GEOSHARDED"
+  provider_name                = "AWS"
+  provider_instance_size_name  = "M30"
+  disk_size_gb                 = 80
+  auto_scaling_compute_enabled = true
+  cloud_backup                 = true
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+      read_only_nodes = 1
+    }
+  }
+  replication_specs {
+    zone_name  = "Zone 2"
+    num_shards = 1
+    regions_config {
+      region_name     = "EU_WEST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+  advanced_configuration {
+    oplog_size_mb = 1000
+  }
+
+  lifecycle {
+    ignore_changes = [
+      provider_instance_size_name,
+      auto_scaling_compute_enabled,
+      cloud_backup,
+      replication_specs[1].zone_name,
+      advanced_configuration[0].oplog_size_mb,
+      provider_encrypt_ebs_volume,
+      tags,
+    ]
+    precondition {
+      condition     = self.provider_instance_size_name != "M10" && self.disk_size_gb >= 40
+      error_message = "Invalid size for ${self.name}."
+    }
+    postcondition {
+      condition     = self.replication_specs[0].id != ""
+      error_message = "Zone id must be set."
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "sharded" {
+  project_id                  = var.project_id
+  name                        = "sharded"
+  cluster_type                = "SHARDED"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M30"
+  replication_specs {
+    num_shards = 2
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [replication_specs[1].zone_name, num_shards, provider_region_name]
+  }
+}
+
+resource "null_resource" "trigger" {
+  lifecycle {
+    replace_triggered_by = [mongodbatlas_cluster.geo.provider_instance_size_name, mongodbatlas_cluster.sharded]
+    precondition {
+      condition     = mongodbatlas_cluster.geo.advanced_configuration[0].oplog_size_mb > 0
+      error_message = "Oplog size of ${mongodbatlas_cluster.sharded.name} must be set."
+    }
+    postcondition {
+      condition     = mongodbatlas_cluster.sharded.snapshot_backup_policy[0].restore_window_days > 0
+      error_message = "Restore window must be set."
+    }
+  }
+}
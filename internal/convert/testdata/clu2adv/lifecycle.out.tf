@@ -0,0 +1,143 @@
+resource "mongodbatlas_advanced_cluster" "geo" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+
+  lifecycle {
+    ignore_changes = [
+      replication_specs[0].region_configs[0].electable_specs.instance_size,
+      replication_specs[0].region_configs[0].read_only_specs.instance_size,
+      replication_specs[1].region_configs[0].electable_specs.instance_size,
+      replication_specs[0].region_configs[0].auto_scaling.compute_enabled,
+      replication_specs[1].region_configs[0].auto_scaling.compute_enabled,
+      backup_enabled,
+      replication_specs[1].zone_name,
+      advanced_configuration.oplog_size_mb,
+      tags
+    ]
+    precondition {
+      condition     = self.provider_instance_size_name != "M10" && self.disk_size_gb >= 40
+      error_message = "Invalid size for ${self.name}."
+    }
+    postcondition {
+      condition     = self.replication_specs[0].zone_id != ""
+      error_message = "Zone id must be set."
+    }
+    # provider_encrypt_ebs_volume was removed from ignore_changes as it doesn't have an equivalent in mongodbatlas_advanced_cluster 2.0.0, add the new paths manually if needed.
+    # self.provider_instance_size_name in condition can't be converted to mongodbatlas_advanced_cluster 2.0.0, please review it.
+    # self.disk_size_gb in condition can't be converted to mongodbatlas_advanced_cluster 2.0.0, please review it.
+  }
+  backup_enabled = true
+  replication_specs = [
+    {
+      zone_name = "Zone 1"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+            disk_size_gb  = 80
+          }
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M30"
+            disk_size_gb  = 80
+          }
+          auto_scaling = {
+            compute_enabled = true
+          }
+        }
+      ]
+    },
+    {
+      zone_name = "Zone 2"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+            disk_size_gb  = 80
+          }
+          auto_scaling = {
+            compute_enabled = true
+          }
+        }
+      ]
+    }
+  ]
+  advanced_configuration = {
+    oplog_size_mb = 1000
+  }
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "sharded" {
+  project_id   = var.project_id
+  name         = "sharded"
+  cluster_type = "SHARDED"
+
+  lifecycle {
+    ignore_changes = [replication_specs[0].region_configs[0].region_name, replication_specs[1].region_configs[0].region_name]
+    # replication_specs[1].zone_name was removed from ignore_changes as it doesn't have an equivalent in mongodbatlas_advanced_cluster 2.0.0, add the new paths manually if needed.
+    # num_shards was removed from ignore_changes as it doesn't have an equivalent in mongodbatlas_advanced_cluster 2.0.0, add the new paths manually if needed.
+  }
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+          }
+        }
+      ]
+    },
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "null_resource" "trigger" {
+  lifecycle {
+    replace_triggered_by = [
+      mongodbatlas_advanced_cluster.geo.replication_specs[0].region_configs[0].electable_specs.instance_size,
+      mongodbatlas_advanced_cluster.geo.replication_specs[0].region_configs[0].read_only_specs.instance_size,
+      mongodbatlas_advanced_cluster.geo.replication_specs[1].region_configs[0].electable_specs.instance_size,
+      mongodbatlas_advanced_cluster.sharded
+    ]
+    precondition {
+      condition     = mongodbatlas_advanced_cluster.geo.advanced_configuration.oplog_size_mb > 0
+      error_message = "Oplog size of ${mongodbatlas_advanced_cluster.sharded.name} must be set."
+    }
+    postcondition {
+      condition     = mongodbatlas_cluster.sharded.snapshot_backup_policy[0].restore_window_days > 0
+      error_message = "Restore window must be set."
+    }
+    # mongodbatlas_cluster.sharded.snapshot_backup_policy[0].restore_window_days in condition can't be converted to mongodbatlas_advanced_cluster 2.0.0, please review it.
+  }
+}
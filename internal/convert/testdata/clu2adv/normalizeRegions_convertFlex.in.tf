@@ -0,0 +1,54 @@
+resource "mongodbatlas_cluster" "normalized" {
+  project_id                  = var.project_id
+  name                        = "cluster-normalized"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "us-east-1" # cloud-native name
+      electable_nodes = 3
+      priority        = 7
+    }
+    regions_config {
+      region_name     = "US_WEST1" # typo
+      electable_nodes = 2
+      priority        = 6
+    }
+    regions_config {
+      region_name     = "us-central1" # GCP region
+      read_only_nodes = 1
+      priority        = 0
+    }
+    regions_config {
+      region_name     = "ATLANTIS"
+      read_only_nodes = 1
+      priority        = 0
+    }
+    regions_config {
+      region_name     = var.region # not checked
+      read_only_nodes = 1
+      priority        = 0
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "free_cluster" {
+  project_id                  = var.project_id
+  name                        = "cluster-free"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AZURE"
+  provider_region_name        = "westeurope"
+  provider_instance_size_name = "M0"
+}
+
+resource "mongodbatlas_cluster" "flex" {
+  project_id                  = var.project_id
+  name                        = "cluster-flex"
+  provider_name               = "TENANT"
+  backing_provider_name       = "GCP"
+  provider_region_name        = "us-central1"
+  provider_instance_size_name = "M5"
+}
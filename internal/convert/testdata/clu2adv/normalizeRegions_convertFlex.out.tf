@@ -0,0 +1,102 @@
+resource "mongodbatlas_advanced_cluster" "normalized" {
+  project_id   = var.project_id
+  name         = "cluster-normalized"
+  cluster_type = "REPLICASET"
+
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "US_WEST1"
+          priority      = 6
+          electable_specs = {
+            node_count    = 2
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "us-central1"
+          priority      = 0
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "ATLANTIS"
+          priority      = 0
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = var.region
+          priority      = 0
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+  # region_name us-east-1 was changed to the Atlas region name US_EAST_1.
+  # region_name US_WEST1 is not a valid AWS region in Atlas, did you mean US_WEST_1?
+  # region_name us-central1 is not a valid AWS region in Atlas, it's a region of GCP.
+  # region_name ATLANTIS is not a valid AWS region in Atlas.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "free_cluster" {
+  project_id   = var.project_id
+  name         = "cluster-free"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = "EUROPE_WEST"
+          provider_name         = "TENANT"
+          backing_provider_name = "AZURE"
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+  # region_name westeurope was changed to the Atlas region name EUROPE_WEST.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "cluster-flex"
+  provider_settings = {
+    backing_provider_name = "GCP"
+    region_name           = "CENTRAL_US"
+  }
+  # region_name us-central1 was changed to the Atlas region name CENTRAL_US.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
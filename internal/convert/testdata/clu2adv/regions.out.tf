@@ -0,0 +1,113 @@
+resource "mongodbatlas_advanced_cluster" "multi_region" {
+  project_id   = var.project_id
+  name         = "cluster-multi-region"
+  cluster_type = "REPLICASET"
+
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "us-east-1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "US_WEST1"
+          priority      = 6
+          electable_specs = {
+            node_count    = 2
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "us-central1"
+          priority      = 0
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "ATLANTIS"
+          priority      = 0
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M10"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = var.region
+          priority      = 0
+          read_only_specs = {
+            node_count    = 1
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+  # region_name us-east-1 is not a valid AWS region in Atlas, did you mean US_EAST_1?
+  # region_name US_WEST1 is not a valid AWS region in Atlas, did you mean US_WEST_1?
+  # region_name us-central1 is not a valid AWS region in Atlas, it's a region of GCP.
+  # region_name ATLANTIS is not a valid AWS region in Atlas.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "free_cluster" {
+  project_id   = var.project_id
+  name         = "cluster-free"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority              = 7
+          region_name           = "westeurope"
+          provider_name         = "TENANT"
+          backing_provider_name = "AZURE"
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+  # region_name westeurope is not a valid AZURE region in Atlas, did you mean EUROPE_WEST?
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "valid" {
+  project_id   = var.project_id
+  name         = "cluster-valid"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          priority      = 7
+          region_name   = "CENTRAL_US"
+          provider_name = "GCP"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
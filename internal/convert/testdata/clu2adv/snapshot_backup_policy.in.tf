@@ -0,0 +1,132 @@
+resource "mongodbatlas_cluster" "backup" {
+  project_id                  = var.project_id
+  name                        = "backup"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  cloud_backup                = true
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+
+  snapshot_backup_policy {
+    reference_hour_of_day    = 3
+    reference_minute_of_hour = 45
+    restore_window_days      = 4
+    update_snapshots         = false
+    cluster_id               = "5d0f1f73cf09a29120e173cf"
+
+    policies {
+      id = "5f0747cad187d8609a72f546"
+      policy_item {
+        id                 = "5f0747cad187d8609a72f547"
+        frequency_interval = 6
+        frequency_type     = "hourly"
+        retention_unit     = "days"
+        retention_value    = 2
+      }
+      policy_item {
+        frequency_interval = 1
+        frequency_type     = "daily"
+        retention_unit     = "days"
+        retention_value    = 7
+      }
+      policy_item {
+        frequency_interval = 6
+        frequency_type     = "weekly"
+        retention_unit     = "weeks"
+        retention_value    = 4
+      }
+      policy_item {
+        frequency_interval = 1
+        frequency_type     = "weekly"
+        retention_unit     = "weeks"
+        retention_value    = 8
+      }
+      policy_item {
+        frequency_interval = 40
+        frequency_type     = "monthly"
+        retention_unit     = "months"
+        retention_value    = 12
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "warnings" {
+  for_each                    = var.clusters
+  project_id                  = var.project_id
+  name                        = each.key
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+
+  snapshot_backup_policy {
+    restore_window_days = each.value.restore_window_days
+    policies {
+      policy_item {
+        frequency_interval = 12
+        frequency_type     = "hourly"
+        retention_unit     = "days"
+        retention_value    = 1
+      }
+      policy_item {
+        frequency_interval = 6
+        frequency_type     = "hourly"
+        retention_unit     = "days"
+        retention_value    = 2
+      }
+      policy_item {
+        frequency_interval = 1
+        frequency_type     = each.value.frequency_type
+        retention_unit     = "days"
+        retention_value    = 7
+      }
+      dynamic "policy_item" {
+        for_each = each.value.policy_items
+        content {
+          frequency_interval = policy_item.value.frequency_interval
+          frequency_type     = policy_item.value.frequency_type
+          retention_unit     = policy_item.value.retention_unit
+          retention_value    = policy_item.value.retention_value
+        }
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "empty" {
+  project_id                  = var.project_id
+  name                        = "empty"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  cloud_backup                = true
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+
+  snapshot_backup_policy {
+  }
+}
@@ -0,0 +1,135 @@
+resource "mongodbatlas_advanced_cluster" "backup" {
+  project_id   = var.project_id
+  name         = "backup"
+  cluster_type = "REPLICASET"
+
+
+  backup_enabled = true
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "backup" {
+  project_id               = mongodbatlas_advanced_cluster.backup.project_id
+  cluster_name             = mongodbatlas_advanced_cluster.backup.name
+  reference_hour_of_day    = 3
+  reference_minute_of_hour = 45
+  restore_window_days      = 4
+  update_snapshots         = false
+  policy_item_hourly {
+    frequency_interval = 6
+    retention_unit     = "days"
+    retention_value    = 2
+  }
+  policy_item_daily {
+    frequency_interval = 1
+    retention_unit     = "days"
+    retention_value    = 7
+  }
+  policy_item_weekly {
+    frequency_interval = 6
+    retention_unit     = "weeks"
+    retention_value    = 4
+  }
+  policy_item_weekly {
+    frequency_interval = 1
+    retention_unit     = "weeks"
+    retention_value    = 8
+  }
+  policy_item_monthly {
+    frequency_interval = 40
+    retention_unit     = "months"
+    retention_value    = 12
+  }
+
+  # Attributes of snapshot_backup_policy not supported in mongodbatlas_cloud_backup_schedule were removed: cluster_id, policies.id, policy_item.id.
+  # Generated by atlas-cli-plugin-terraform.
+}
+
+resource "mongodbatlas_advanced_cluster" "warnings" {
+  for_each     = var.clusters
+  project_id   = var.project_id
+  name         = each.key
+  cluster_type = "REPLICASET"
+
+
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "warnings" {
+  for_each            = var.clusters
+  project_id          = mongodbatlas_advanced_cluster.warnings[each.key].project_id
+  cluster_name        = mongodbatlas_advanced_cluster.warnings[each.key].name
+  restore_window_days = each.value.restore_window_days
+  policy_item_hourly {
+    frequency_interval = 12
+    retention_unit     = "days"
+    retention_value    = 1
+  }
+
+  # dynamic policy_item blocks in snapshot_backup_policy can't be converted, add them manually.
+  # only one policy_item with frequency_type hourly is supported, the others were removed.
+  # policy_item with frequency_type each.value.frequency_type can't be converted, add it manually.
+  # mongodbatlas_cloud_backup_schedule requires backup_enabled = true in mongodbatlas_advanced_cluster.warnings.
+  # Generated by atlas-cli-plugin-terraform.
+}
+
+resource "mongodbatlas_advanced_cluster" "empty" {
+  project_id   = var.project_id
+  name         = "empty"
+  cluster_type = "REPLICASET"
+
+
+  backup_enabled = true
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
@@ -0,0 +1,46 @@
+resource "mongodbatlas_cluster" "cluster" {
+  project_id                  = var.project_id
+  name                        = "cluster"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  cloud_backup                = true
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "schedule" {
+  project_id   = mongodbatlas_cluster.cluster.project_id
+  cluster_name = mongodbatlas_cluster.cluster.name
+
+  copy_settings {
+    cloud_provider      = "AWS"
+    frequencies         = ["HOURLY", "DAILY"]
+    region_name         = "US_WEST_2"
+    replication_spec_id = mongodbatlas_cluster.cluster.replication_specs.0.id
+    should_copy_oplogs  = false
+  }
+
+  copy_settings {
+    cloud_provider      = "AWS"
+    frequencies         = ["DAILY"]
+    region_name         = "EU_WEST_1"
+    replication_spec_id = mongodbatlas_cluster.other.replication_specs[0].id # not converted in this file
+    should_copy_oplogs  = false
+  }
+}
+
+output "replication_spec_ids" {
+  value = [for key, spec in mongodbatlas_cluster.cluster.replication_specs[*].id : "${key}-${spec}"]
+}
+
+output "cluster_id" {
+  value = mongodbatlas_cluster.cluster.id
+}
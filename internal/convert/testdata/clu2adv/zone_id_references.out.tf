@@ -0,0 +1,56 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+
+  backup_enabled = true
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "schedule" {
+  project_id   = mongodbatlas_cluster.cluster.project_id
+  cluster_name = mongodbatlas_cluster.cluster.name
+
+  copy_settings {
+    cloud_provider     = "AWS"
+    frequencies        = ["HOURLY", "DAILY"]
+    region_name        = "US_WEST_2"
+    zone_id            = mongodbatlas_advanced_cluster.cluster.replication_specs.0.zone_id
+    should_copy_oplogs = false
+    # Updated by atlas-cli-plugin-terraform, references to replication_specs id were changed to zone_id, please review the changes.
+  }
+
+  copy_settings {
+    cloud_provider      = "AWS"
+    frequencies         = ["DAILY"]
+    region_name         = "EU_WEST_1"
+    replication_spec_id = mongodbatlas_cluster.other.replication_specs[0].id # not converted in this file
+    should_copy_oplogs  = false
+  }
+}
+
+output "replication_spec_ids" {
+  value = [for key, spec in mongodbatlas_advanced_cluster.cluster.replication_specs[*].zone_id : "${key}-${spec}"]
+  # Updated by atlas-cli-plugin-terraform, references to replication_specs id were changed to zone_id, please review the changes.
+}
+
+output "cluster_id" {
+  value = mongodbatlas_cluster.cluster.id
+}
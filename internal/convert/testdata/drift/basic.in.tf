@@ -0,0 +1,82 @@
+resource "mongodbatlas_advanced_cluster" "from_cluster" {
+  project_id     = var.project_id
+  name           = "from-cluster"
+  cluster_type   = "REPLICASET"
+  backup_enabled = true
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = var.region
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+            disk_size_gb  = 40
+          }
+          auto_scaling = {
+            disk_gb_enabled = true
+            compute_enabled = false
+          }
+        }
+      ]
+    }
+  ]
+  tags = {
+    environment = "dev"
+  }
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "no_drift" {
+  project_id   = var.project_id
+  name         = "no-drift"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+            disk_size_gb  = 10
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "count" {
+  count        = 2
+  project_id   = var.project_id
+  name         = "count-${count.index}"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M20"
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "new" {
+  project_id   = var.project_id
+  name         = "new"
+  cluster_type = "REPLICASET"
+}
@@ -0,0 +1,4 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id = var.project_id
+  name       = "cluster"
+}
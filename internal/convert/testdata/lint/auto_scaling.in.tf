@@ -0,0 +1,47 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs {
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        instance_size = "M50"
+        node_count    = 3
+      }
+      analytics_specs {
+        instance_size = "M10"
+        node_count    = 1
+      }
+      auto_scaling {
+        compute_enabled           = true
+        compute_min_instance_size = "M10"
+        compute_max_instance_size = "M40"
+      }
+      analytics_auto_scaling {
+        compute_enabled           = true
+        compute_min_instance_size = "M30"
+        compute_max_instance_size = "M20"
+      }
+    }
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_WEST_2"
+      priority      = 6
+      electable_specs {
+        instance_size = "M50"
+        node_count    = 2
+      }
+      analytics_auto_scaling {
+        disk_gb_enabled = true
+      }
+      auto_scaling {
+        compute_enabled           = true
+        compute_min_instance_size = "M10"
+        compute_max_instance_size = "M60"
+      }
+    }
+  }
+}
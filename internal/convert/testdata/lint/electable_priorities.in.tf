@@ -0,0 +1,62 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "GEOSHARDED"
+  replication_specs = [
+    {
+      zone_name = "Zone 1"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 6
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 2
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_2"
+          priority      = 6
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 2
+          }
+        }
+      ]
+    },
+    {
+      zone_name = "Zone 2"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 5
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_2"
+          priority      = 6
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+        }
+      ]
+    }
+  ]
+}
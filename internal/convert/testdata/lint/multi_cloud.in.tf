@@ -0,0 +1,29 @@
+resource "mongodbatlas_advanced_cluster" "multi_cloud" {
+  project_id   = var.project_id
+  name         = "multi-cloud"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_2"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 2
+          }
+        },
+        {
+          provider_name = "AZURE"
+          region_name   = "US_EAST_2"
+          priority      = 6
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 1
+          }
+        }
+      ]
+    }
+  ]
+}
@@ -0,0 +1,36 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = var.region
+          priority      = 7
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "dynamic" {
+  project_id   = var.project_id
+  name         = "dynamic"
+  cluster_type = "REPLICASET"
+  dynamic "replication_specs" {
+    for_each = var.replication_specs
+    content {
+      region_configs {
+        provider_name = "AWS"
+        region_name   = "US_EAST_1"
+        priority      = 7
+      }
+    }
+  }
+}
@@ -0,0 +1,59 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "us-east-1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = 3
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EASTERN_US"
+          priority      = 0
+          read_only_specs = {
+            instance_size = "M10"
+            node_count    = 1
+          }
+        },
+        {
+          provider_name = "GCP"
+          region_name   = "WESTERN_EUROP"
+          priority      = 0
+          read_only_specs = {
+            instance_size = "M10"
+            node_count    = 1
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "tenant" {
+  project_id   = var.project_id
+  name         = "tenant"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name         = "TENANT"
+          backing_provider_name = "AZURE"
+          region_name           = "eastus2"
+          priority              = 7
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+}
@@ -0,0 +1,103 @@
+resource "mongodbatlas_advanced_cluster" "v2" {
+  project_id   = var.project_id
+  name         = "v2"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 3
+          }
+          analytics_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+          auto_scaling = {
+            compute_enabled           = true
+            compute_min_instance_size = "M10"
+            compute_max_instance_size = "M40"
+          }
+          analytics_auto_scaling = {
+            compute_enabled = false
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "US_WEST_2"
+          priority      = 6
+          electable_specs = {
+            instance_size = "M30"
+            node_count    = 2
+          }
+          read_only_specs = {
+            instance_size = "M30"
+            node_count    = 1
+          }
+          auto_scaling = {
+            compute_enabled           = true
+            compute_min_instance_size = "M10"
+            compute_max_instance_size = "M40"
+          }
+        },
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 0
+          read_only_specs = {
+            instance_size = "M30"
+            node_count    = 2
+          }
+          auto_scaling = {
+            compute_enabled           = true
+            compute_min_instance_size = "M10"
+            compute_max_instance_size = "M40"
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "v1" {
+  project_id   = var.project_id
+  name         = "v1"
+  cluster_type = "SHARDED"
+  replication_specs {
+    num_shards = 2
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        instance_size = "M40_NVME"
+        node_count    = 5
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_advanced_cluster" "tenant" {
+  project_id   = var.project_id
+  name         = "tenant"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name         = "TENANT"
+          backing_provider_name = "AWS"
+          region_name           = "US_EAST_1"
+          priority              = 7
+          electable_specs = {
+            instance_size = "M0"
+          }
+        }
+      ]
+    }
+  ]
+}
@@ -0,0 +1,88 @@
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  aws_kms_config {
+    enabled                = true
+    customer_master_key_id = var.kms_key_id
+    region                 = "US_EAST_1"
+    role_id                = var.role_id
+  }
+}
+
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs {
+    instance_size = "S20_HIGHCPU_NVME"
+    node_count    = 2
+  }
+  timeouts {
+    create = "30m"
+  }
+}
+
+resource "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+  provider_settings {
+    backing_provider_name = "AWS"
+    region_name           = "US_EAST_1"
+  }
+  tags {
+    key   = "environment"
+    value = "dev"
+  }
+  tags {
+    key   = "team"
+    value = var.team
+  }
+}
+
+# blocks in provider 2.0.0 are kept
+resource "mongodbatlas_database_user" "user" {
+  project_id         = var.project_id
+  username           = "user"
+  password           = var.password
+  auth_database_name = "admin"
+  roles {
+    role_name     = "readWrite"
+    database_name = "db"
+  }
+}
+
+# advanced_cluster is converted by advancedClusterToV2
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs {
+    region_configs {
+      priority      = 7
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 3
+      }
+    }
+  }
+}
+
+# resources not in the provider schema are kept
+resource "aws_kms_key" "key" {
+  description = "key"
+  timeouts {
+    create = "10m"
+  }
+}
+
+output "ear_valid" {
+  value = mongodbatlas_encryption_at_rest.ear.aws_kms_config[0].valid
+}
+
+output "flex_region" {
+  value = mongodbatlas_flex_cluster.flex.provider_settings.0.region_name
+}
+
+output "search_specs" {
+  value = mongodbatlas_search_deployment.search.specs[0].instance_size
+}
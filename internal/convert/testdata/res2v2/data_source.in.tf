@@ -0,0 +1,12 @@
+data "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+}
+
+output "standard_srv" {
+  value = data.mongodbatlas_flex_cluster.flex.connection_strings[0].standard_srv
+}
+
+output "backing_provider" {
+  value = data.mongodbatlas_flex_clusters.all.results[0].provider_settings[0].backing_provider_name
+}
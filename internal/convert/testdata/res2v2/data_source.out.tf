@@ -0,0 +1,14 @@
+data "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+}
+
+output "standard_srv" {
+  value = data.mongodbatlas_flex_cluster.flex.connection_strings.standard_srv
+  # Updated by atlas-cli-plugin-terraform, references to nested attributes were changed to the Provider 2.0.0 schema, please review the changes.
+}
+
+output "backing_provider" {
+  value = data.mongodbatlas_flex_clusters.all.results[0].provider_settings.backing_provider_name
+  # Updated by atlas-cli-plugin-terraform, references to nested attributes were changed to the Provider 2.0.0 schema, please review the changes.
+}
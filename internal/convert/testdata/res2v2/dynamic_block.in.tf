@@ -0,0 +1,53 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  dynamic "specs" {
+    for_each = var.search_specs
+    content {
+      instance_size = specs.value.instance_size
+      node_count    = specs.value.node_count
+    }
+  }
+}
+
+resource "mongodbatlas_search_deployment" "search_iterator" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  dynamic "specs" {
+    for_each = var.search_specs
+    iterator = spec
+    content {
+      instance_size = spec.value.instance_size
+      node_count    = spec.value.node_count
+    }
+  }
+}
+
+resource "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+  provider_settings {
+    backing_provider_name = "AWS"
+    region_name           = "US_EAST_1"
+  }
+  dynamic "tags" {
+    for_each = var.tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "backup" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  dynamic "policy_item_daily" {
+    for_each = var.daily
+    content {
+      frequency_interval = policy_item_daily.value.interval
+      retention_unit     = "days"
+      retention_value    = 7
+    }
+  }
+}
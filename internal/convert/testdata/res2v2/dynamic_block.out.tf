@@ -0,0 +1,50 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs = [
+    for specs in var.search_specs : {
+      instance_size = specs.instance_size
+      node_count    = specs.node_count
+    }
+  ]
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+resource "mongodbatlas_search_deployment" "search_iterator" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs = [
+    for spec in var.search_specs : {
+      instance_size = spec.instance_size
+      node_count    = spec.node_count
+    }
+  ]
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+resource "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+  provider_settings = {
+    backing_provider_name = "AWS"
+    region_name           = "US_EAST_1"
+  }
+  tags = var.tags
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "backup" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  dynamic "policy_item_daily" {
+    for_each = var.daily
+    content {
+      frequency_interval = policy_item_daily.value.interval
+      retention_unit     = "days"
+      retention_value    = 7
+    }
+  }
+}
@@ -0,0 +1,15 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs {
+    instance_size = "S20_HIGHCPU_NVME"
+    node_count    = 2
+  }
+  dynamic "specs" {
+    for_each = var.search_specs
+    content {
+      instance_size = specs.value.instance_size
+      node_count    = specs.value.node_count
+    }
+  }
+}
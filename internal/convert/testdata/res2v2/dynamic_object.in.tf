@@ -0,0 +1,9 @@
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  dynamic "aws_kms_config" {
+    for_each = var.aws_kms_config
+    content {
+      enabled = aws_kms_config.value.enabled
+    }
+  }
+}
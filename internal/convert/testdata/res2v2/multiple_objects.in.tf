@@ -0,0 +1,9 @@
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  aws_kms_config {
+    enabled = true
+  }
+  aws_kms_config {
+    enabled = false
+  }
+}
@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 1.26"
+    }
+  }
+}
+
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  google_cloud_kms_config {
+    enabled                 = true
+    key_version_resource_id = var.key_version_resource_id
+    role_id                 = var.role_id
+  }
+}
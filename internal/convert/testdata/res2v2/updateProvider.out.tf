@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 2.0"
+    }
+  }
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
+
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  google_cloud_kms_config = {
+    enabled                 = true
+    key_version_resource_id = var.key_version_resource_id
+    role_id                 = var.role_id
+  }
+
+  # Updated by atlas-cli-plugin-terraform, please review the changes.
+}
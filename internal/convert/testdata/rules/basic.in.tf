@@ -0,0 +1,65 @@
+resource "mongodbatlas_search_index" "index" {
+  project_id                      = var.project_id
+  cluster_name                    = var.cluster_name
+  name                            = "index"
+  wait_for_index_build_completion = true
+  synonyms {
+    id                = "1"
+    analyzer          = "lucene.standard"
+    name              = "synonym_test"
+    source_collection = "collection_test"
+  }
+  synonyms {
+    analyzer          = "lucene.simple"
+    name              = "synonym_other"
+    source_collection = "collection_other"
+  }
+  timeouts {
+    create = "30m"
+  }
+}
+
+resource "mongodbatlas_custom_db_role" "role" {
+  project_id = var.project_id
+  role_name  = "role"
+  actions {
+    action = "UPDATE"
+    resources {
+      collection_name = ""
+      database_name   = "db1"
+    }
+    resources {
+      collection_name = ""
+      database_name   = "db2"
+    }
+  }
+  actions {
+    action = "INSERT"
+    resources {
+      cluster = true
+    }
+  }
+}
+
+module "cluster" {
+  source                      = "git::https://example.com/atlas-cluster.git"
+  instance_size               = "M10"
+  provider_encrypt_ebs_volume = true
+}
+
+module "other" {
+  source        = "git::https://example.com/other.git"
+  instance_size = "M10"
+}
+
+module "no_source" {
+  instance_size = "M10"
+}
+
+data "mongodbatlas_project" "project" {
+  name = "project"
+  tags {
+    key   = "env"
+    value = "dev"
+  }
+}
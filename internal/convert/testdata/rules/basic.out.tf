@@ -0,0 +1,80 @@
+resource "mongodbatlas_search_index" "index" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  name         = "index"
+  synonyms = [
+    {
+      analyzer          = "lucene.standard"
+      name              = "synonym_test"
+      source_collection = "collection_test"
+    },
+    {
+      analyzer          = "lucene.simple"
+      name              = "synonym_other"
+      source_collection = "collection_other"
+    }
+  ]
+  timeouts = {
+    create = "30m"
+  }
+
+  # wait_for_index_build_completion was removed by rule search_index.
+  # synonyms.id was removed by rule search_index.
+  # Updated by atlas-cli-plugin-terraform with rule search_index, please review the changes.
+}
+
+resource "mongodbatlas_custom_db_role" "role" {
+  project_id = var.project_id
+  role_name  = "role"
+  actions = [
+    {
+      name = "UPDATE"
+      resources = [
+        {
+          collection_name = ""
+          database_name   = "db1"
+        },
+        {
+          collection_name = ""
+          database_name   = "db2"
+        }
+      ]
+    },
+    {
+      name = "INSERT"
+      resources = [
+        {
+          cluster = true
+        }
+      ]
+    }
+  ]
+
+  # Updated by atlas-cli-plugin-terraform with rule custom_db_role, please review the changes.
+}
+
+module "cluster" {
+  source                  = "git::https://example.com/atlas-cluster.git"
+  electable_instance_size = "M10"
+
+  # provider_encrypt_ebs_volume was removed by rule wrapper_module.
+  # Updated by atlas-cli-plugin-terraform with rule wrapper_module, please review the changes.
+}
+
+module "other" {
+  source        = "git::https://example.com/other.git"
+  instance_size = "M10"
+}
+
+module "no_source" {
+  instance_size = "M10"
+}
+
+data "mongodbatlas_project" "project" {
+  name = "project"
+  tags = {
+    env = "dev"
+  }
+
+  # Updated by atlas-cli-plugin-terraform with rule project_data_source, please review the changes.
+}
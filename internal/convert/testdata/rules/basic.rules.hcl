@@ -0,0 +1,23 @@
+rule "search_index" {
+  resource_type     = "mongodbatlas_search_index"
+  remove_attributes = ["wait_for_index_build_completion", "synonyms.id"]
+  blocks_to_list    = ["synonyms"]
+  blocks_to_object  = ["timeouts"]
+}
+
+rule "custom_db_role" {
+  resource_type     = "mongodbatlas_custom_db_role"
+  rename_attributes = { "actions.action" = "name" }
+  blocks_to_list    = ["actions", "actions.resources"]
+}
+
+rule "wrapper_module" {
+  module_source     = "git::https://example.com/atlas-cluster.git"
+  rename_attributes = { instance_size = "electable_instance_size" }
+  remove_attributes = ["provider_encrypt_ebs_volume"]
+}
+
+rule "project_data_source" {
+  data_source_type = "mongodbatlas_project"
+  blocks_to_map    = ["tags"]
+}
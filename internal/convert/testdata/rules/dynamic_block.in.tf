@@ -0,0 +1,9 @@
+resource "mongodbatlas_search_index" "index" {
+  name = "index"
+  dynamic "synonyms" {
+    for_each = var.synonyms
+    content {
+      name = synonyms.value.name
+    }
+  }
+}
@@ -0,0 +1,4 @@
+rule "search_index" {
+  resource_type  = "mongodbatlas_search_index"
+  blocks_to_list = ["synonyms"]
+}
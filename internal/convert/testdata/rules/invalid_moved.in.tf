@@ -0,0 +1,3 @@
+data "mongodbatlas_privatelink_endpoint_service_serverless" "endpoint" {
+  project_id = var.project_id
+}
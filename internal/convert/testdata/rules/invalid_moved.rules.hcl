@@ -0,0 +1,5 @@
+rule "private_endpoint_data_source" {
+  data_source_type = "mongodbatlas_privatelink_endpoint_service_serverless"
+  rename_type      = "mongodbatlas_privatelink_endpoint_service"
+  moved            = true
+}
@@ -0,0 +1,3 @@
+resource "mongodbatlas_project" "project" {
+  name = "project"
+}
@@ -0,0 +1,4 @@
+rule "invalid" {
+  resource_type    = "mongodbatlas_project"
+  data_source_type = "mongodbatlas_project"
+}
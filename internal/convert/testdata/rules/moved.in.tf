@@ -0,0 +1,22 @@
+resource "mongodbatlas_privatelink_endpoint_service_serverless" "endpoint" {
+  project_id    = var.project_id
+  instance_name = "instance"
+  endpoint_id   = var.endpoint_id
+}
+
+data "mongodbatlas_privatelink_endpoint_service_serverless" "endpoint" {
+  project_id = var.project_id
+}
+
+resource "mongodbatlas_project_ip_access_list" "endpoint" {
+  project_id = mongodbatlas_privatelink_endpoint_service_serverless.endpoint.project_id
+  comment    = "endpoint ${mongodbatlas_privatelink_endpoint_service_serverless.endpoint.endpoint_id}"
+}
+
+output "endpoint_ids" {
+  value = [
+    mongodbatlas_privatelink_endpoint_service_serverless.endpoint.endpoint_id,
+    data.mongodbatlas_privatelink_endpoint_service_serverless.endpoint.endpoint_id,
+    var.settings.mongodbatlas_privatelink_endpoint_service_serverless.endpoint,
+  ]
+}
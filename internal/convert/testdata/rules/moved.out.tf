@@ -0,0 +1,34 @@
+resource "mongodbatlas_privatelink_endpoint_service" "endpoint" {
+  project_id    = var.project_id
+  instance_name = "instance"
+  endpoint_id   = var.endpoint_id
+
+  # Updated by atlas-cli-plugin-terraform with rule private_endpoint, please review the changes.
+}
+
+data "mongodbatlas_privatelink_endpoint_service_serverless" "endpoint" {
+  project_id = var.project_id
+}
+
+resource "mongodbatlas_project_ip_access_list" "endpoint" {
+  project_id = mongodbatlas_privatelink_endpoint_service.endpoint.project_id
+  comment    = "endpoint ${mongodbatlas_privatelink_endpoint_service.endpoint.endpoint_id}"
+  # Updated by atlas-cli-plugin-terraform, references to types renamed by rules were changed, please review the changes.
+}
+
+output "endpoint_ids" {
+  value = [
+    mongodbatlas_privatelink_endpoint_service.endpoint.endpoint_id,
+    data.mongodbatlas_privatelink_endpoint_service_serverless.endpoint.endpoint_id,
+    var.settings.mongodbatlas_privatelink_endpoint_service_serverless.endpoint,
+  ]
+  # Updated by atlas-cli-plugin-terraform, references to types renamed by rules were changed, please review the changes.
+}
+
+# Moved blocks
+# Note: moved blocks across resource types require Terraform 1.8 or later and support in the provider to move from the old type.
+
+moved {
+  from = mongodbatlas_privatelink_endpoint_service_serverless.endpoint
+  to   = mongodbatlas_privatelink_endpoint_service.endpoint
+}
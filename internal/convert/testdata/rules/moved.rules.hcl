@@ -0,0 +1,5 @@
+rule "private_endpoint" {
+  resource_type = "mongodbatlas_privatelink_endpoint_service_serverless"
+  rename_type   = "mongodbatlas_privatelink_endpoint_service"
+  moved         = true
+}
@@ -0,0 +1,59 @@
+resource "mongodbatlas_serverless_instance" "basic" {
+  project_id                              = var.project_id
+  name                                    = "serverless-basic"
+  provider_settings_backing_provider_name = "AWS"
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = "US_EAST_1"
+  termination_protection_enabled          = true
+
+  tags {
+    key   = "environment"
+    value = "dev"
+  }
+  tags {
+    key   = "team"
+    value = var.team
+  }
+}
+
+resource "mongodbatlas_serverless_instance" "backup" {
+  for_each                                = var.instances
+  project_id                              = var.project_id
+  name                                    = each.key
+  provider_settings_backing_provider_name = each.value.provider
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = each.value.region
+  continuous_backup_enabled               = true
+  auto_indexing                           = false
+
+  dynamic "tags" {
+    for_each = var.tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+
+  timeouts {
+    create = "30m"
+  }
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+resource "mongodbatlas_advanced_cluster" "untouched" {
+  project_id   = var.project_id
+  name         = "untouched"
+  cluster_type = "REPLICASET"
+}
+
+data "mongodbatlas_serverless_instance" "basic" {
+  project_id = mongodbatlas_serverless_instance.basic.project_id
+  name       = mongodbatlas_serverless_instance.basic.name
+}
+
+data "mongodbatlas_serverless_instances" "all" {
+  project_id = var.project_id
+}
@@ -0,0 +1 @@
+invalid {
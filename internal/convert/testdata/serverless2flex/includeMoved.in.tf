@@ -0,0 +1,26 @@
+resource "mongodbatlas_serverless_instance" "moved" {
+  project_id                              = var.project_id
+  name                                    = "serverless-moved"
+  provider_settings_backing_provider_name = "AWS"
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = "US_EAST_1"
+  termination_protection_enabled          = true
+
+  tags {
+    key   = "environment"
+    value = "dev"
+  }
+  tags {
+    key   = "team"
+    value = var.team
+  }
+}
+
+resource "mongodbatlas_serverless_instance" "count" {
+  count                                   = 2
+  project_id                              = var.project_id
+  name                                    = "serverless-${count.index}"
+  provider_settings_backing_provider_name = "GCP"
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = "CENTRAL_US"
+}
@@ -0,0 +1,43 @@
+resource "mongodbatlas_flex_cluster" "moved" {
+  project_id                     = var.project_id
+  name                           = "serverless-moved"
+  termination_protection_enabled = true
+
+  provider_settings = {
+    backing_provider_name = "AWS"
+    region_name           = "US_EAST_1"
+  }
+  tags = {
+    environment = "dev"
+    team        = var.team
+  }
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_flex_cluster" "count" {
+  count      = 2
+  project_id = var.project_id
+  name       = "serverless-${count.index}"
+  provider_settings = {
+    backing_provider_name = "GCP"
+    region_name           = "CENTRAL_US"
+  }
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+# Moved blocks
+# Note: Remember to remove or comment out the old cluster definitions.
+
+moved {
+  from = mongodbatlas_serverless_instance.moved
+  to   = mongodbatlas_flex_cluster.moved
+}
+
+moved {
+  from = mongodbatlas_serverless_instance.count
+  to   = mongodbatlas_flex_cluster.count
+}
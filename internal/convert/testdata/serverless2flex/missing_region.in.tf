@@ -0,0 +1,6 @@
+resource "mongodbatlas_serverless_instance" "missing_region" {
+  project_id                              = var.project_id
+  name                                    = "serverless"
+  provider_settings_backing_provider_name = "AWS"
+  provider_settings_provider_name         = "SERVERLESS"
+}
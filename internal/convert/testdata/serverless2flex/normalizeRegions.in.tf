@@ -0,0 +1,15 @@
+resource "mongodbatlas_serverless_instance" "normalized" {
+  project_id                              = var.project_id
+  name                                    = "serverless-normalized"
+  provider_settings_backing_provider_name = "AWS"
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = "eu-central-1"
+}
+
+resource "mongodbatlas_serverless_instance" "invalid" {
+  project_id                              = var.project_id
+  name                                    = "serverless-invalid"
+  provider_settings_backing_provider_name = "AWS"
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = "EU_CENTRL_1"
+}
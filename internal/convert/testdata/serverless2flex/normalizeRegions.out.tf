@@ -0,0 +1,25 @@
+resource "mongodbatlas_flex_cluster" "normalized" {
+  project_id = var.project_id
+  name       = "serverless-normalized"
+  provider_settings = {
+    backing_provider_name = "AWS"
+    region_name           = "EU_CENTRAL_1"
+  }
+  # region_name eu-central-1 was changed to the Atlas region name EU_CENTRAL_1.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_flex_cluster" "invalid" {
+  project_id = var.project_id
+  name       = "serverless-invalid"
+  provider_settings = {
+    backing_provider_name = "AWS"
+    region_name           = "EU_CENTRL_1"
+  }
+  # region_name EU_CENTRL_1 is not a valid AWS region in Atlas, did you mean EU_CENTRAL_1?
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
@@ -0,0 +1,17 @@
+resource "mongodbatlas_cluster" "shared" {
+  project_id                  = "664619d870c247237f4b86a6"
+  name                        = "shared"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M5"
+}
+
+resource "mongodbatlas_cluster" "free" {
+  project_id                  = "664619d870c247237f4b86a6"
+  name                        = "free"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M0"
+}
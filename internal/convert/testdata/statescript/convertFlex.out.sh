@@ -0,0 +1,10 @@
+#!/bin/sh
+# Generated by atlas-cli-plugin-terraform.
+# State migration from mongodbatlas_cluster to mongodbatlas_advanced_cluster, run it in the Terraform directory.
+set -eu
+
+terraform import 'module.db["main"].mongodbatlas_flex_cluster.shared' '664619d870c247237f4b86a6-shared'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.shared'
+
+terraform import 'module.db["main"].mongodbatlas_advanced_cluster.free' '664619d870c247237f4b86a6-free'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.free'
@@ -0,0 +1,51 @@
+resource "mongodbatlas_cluster" "single" {
+  project_id                  = "664619d870c247237f4b86a6"
+  name                        = "single"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "count" {
+  count                       = 2
+  project_id                  = "664619d870c247237f4b86a6"
+  name                        = "count-${count.index}"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "for_each" {
+  for_each = {
+    "prod's" = "US_EAST_1"
+    dev      = "US_WEST_2"
+  }
+  project_id                  = "664619d870c247237f4b86a6"
+  name                        = "cluster-${each.key}"
+  provider_name               = "AWS"
+  provider_region_name        = each.value
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "variable_name" {
+  project_id                  = "664619d870c247237f4b86a6"
+  name                        = var.cluster_name
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "variable_for_each" {
+  for_each                    = var.clusters
+  project_id                  = var.project_id
+  name                        = each.key
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_advanced_cluster" "not_migrated" {
+  project_id   = var.project_id
+  name         = "adv"
+  cluster_type = "REPLICASET"
+}
@@ -0,0 +1,27 @@
+#!/bin/sh
+# Generated by atlas-cli-plugin-terraform.
+# State migration from mongodbatlas_cluster to mongodbatlas_advanced_cluster, run it in the Terraform directory.
+set -eu
+
+terraform import 'module.db["main"].mongodbatlas_advanced_cluster.single' '664619d870c247237f4b86a6-single'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.single'
+
+terraform import 'module.db["main"].mongodbatlas_advanced_cluster.count[0]' '664619d870c247237f4b86a6-count-0'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.count[0]'
+
+terraform import 'module.db["main"].mongodbatlas_advanced_cluster.count[1]' '664619d870c247237f4b86a6-count-1'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.count[1]'
+
+terraform import 'module.db["main"].mongodbatlas_advanced_cluster.for_each["dev"]' '664619d870c247237f4b86a6-cluster-dev'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.for_each["dev"]'
+
+terraform import 'module.db["main"].mongodbatlas_advanced_cluster.for_each["prod'\''s"]' '664619d870c247237f4b86a6-cluster-prod'\''s'
+terraform state rm 'module.db["main"].mongodbatlas_cluster.for_each["prod'\''s"]'
+
+# module.db["main"].mongodbatlas_cluster.variable_name: import id can't be resolved as attribute name can't be evaluated statically, complete and uncomment the commands.
+# terraform import 'module.db["main"].mongodbatlas_advanced_cluster.variable_name' '664619d870c247237f4b86a6-${var.cluster_name}'
+# terraform state rm 'module.db["main"].mongodbatlas_cluster.variable_name'
+
+# module.db["main"].mongodbatlas_cluster.variable_for_each: instances can't be resolved as for_each can't be evaluated statically, add the commands for each instance.
+# terraform import 'module.db["main"].mongodbatlas_advanced_cluster.variable_for_each' '${var.project_id}-${each.key}'
+# terraform state rm 'module.db["main"].mongodbatlas_cluster.variable_for_each'
@@ -0,0 +1,51 @@
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id     = var.project_id
+  name           = "cluster"
+  cluster_type   = "REPLICASET"
+  disk_size_gb   = 10
+  backup_enabld  = true
+  paused         = "yes"
+  cluster_id     = "id"
+  mongo_db_major_version = 8
+  count          = var.enabled ? 1 : 0
+  tags           = ["tag"]
+  labels         = var.labels
+  replication_specs = [
+    {
+      zone_name = "Zone 1"
+      region_configs = [
+        {
+          priority      = "high"
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          electable_specs = {
+            instance_size = "M10"
+            node_count    = var.node_count
+            nodes         = 3
+          }
+          read_only_specs = [
+            {
+              instance_size = "M10"
+            }
+          ]
+        }
+      ]
+    }
+  ]
+  lifecycle {
+    ignore_changes = [paused]
+  }
+}
+
+# not changed by the conversion, so it's not validated
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  aws_kms_config {
+    enabled = true
+  }
+}
+
+# not in the schema snapshot, so it's not validated
+resource "mongodbatlas_unknown" "unknown" {
+  unknown = true
+}
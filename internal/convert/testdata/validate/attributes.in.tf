@@ -0,0 +1,17 @@
+resource "mongodbatlas_cluster" "cluster" {
+  project_id                  = var.project_id
+  name                        = "cluster"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  provider_region_name        = "US_EAST_1"
+  disk_size_gb                = 10
+}
+
+# not changed by the conversion, so it's not validated
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  aws_kms_config {
+    enabled = true
+  }
+}
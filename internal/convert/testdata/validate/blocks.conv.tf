@@ -0,0 +1,40 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs {
+    instance_size = "S20_HIGHCPU_NVME"
+    node_count    = 2
+  }
+  timeouts = {
+    create = "30m"
+    read   = "5m"
+  }
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "backup" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  policy_item_daily = {
+    frequency_interval = 1
+    retention_unit     = "days"
+    retention_value    = 7
+  }
+  policy_item_weekly {
+    frequency_interval = 1
+    retention_unit     = "weeks"
+    retention_value    = "four"
+  }
+  dynamic "copy_settings" {
+    for_each = var.copy_settings
+    content {
+      zone_id             = copy_settings.value.zone_id
+      replication_spec_id = copy_settings.value.id
+    }
+  }
+  dynamic "snapshot_backup_policy" {
+    for_each = var.policies
+    content {
+      id = snapshot_backup_policy.value.id
+    }
+  }
+}
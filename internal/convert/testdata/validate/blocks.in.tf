@@ -0,0 +1,13 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs {
+    instance_size = "S20_HIGHCPU_NVME"
+    node_count    = 2
+  }
+}
+
+resource "mongodbatlas_cloud_backup_schedule" "backup" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+}
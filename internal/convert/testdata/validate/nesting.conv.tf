@@ -0,0 +1,47 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs = {
+    instance_size = "S20_HIGHCPU_NVME"
+    node_count    = 2
+  }
+}
+
+resource "mongodbatlas_search_deployment" "search_for" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs = [
+    for spec in var.specs : {
+      instance_size = spec.instance_size
+      node_count    = spec.node_count
+      node_type     = "search"
+    }
+  ]
+}
+
+resource "mongodbatlas_search_deployment" "search_map" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs        = { for key, spec in var.specs : key => spec }
+}
+
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  aws_kms_config = [
+    {
+      enabled = true
+    }
+  ]
+  google_cloud_kms_config = var.google_cloud_kms_config
+  azure_key_vault_config = {
+    "enabled"        = true
+    (var.key_name)   = var.key_value
+    key_vault_namee = "vault"
+  }
+}
+
+data "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+  state_name = "IDLE"
+}
@@ -0,0 +1,14 @@
+resource "mongodbatlas_search_deployment" "search" {
+  project_id   = var.project_id
+  cluster_name = var.cluster_name
+  specs {
+    instance_size = "S20_HIGHCPU_NVME"
+    node_count    = 2
+  }
+}
+
+data "mongodbatlas_flex_cluster" "flex" {
+  project_id = var.project_id
+  name       = "flex"
+  state_name = "IDLE"
+}
@@ -0,0 +1,27 @@
+resource "mongodbatlas_search_index" "index" {
+  project_id      = var.project_id
+  cluster_name    = var.cluster_name
+  name            = "index"
+  database        = "db"
+  collection_name = "coll"
+  synonyms {
+    analyzer          = "lucene.standard"
+    name              = "synonyms"
+    source_collection = "synonyms"
+  }
+}
+
+resource "mongodbatlas_project_ip_access_list" "no_blocks" {
+  project_id = var.project_id
+  cidr_block = "10.0.0.0/16"
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+resource "aws_instance" "other_provider" {
+  ami = "ami-123"
+  ebs_block_device {
+    device_name = "/dev/sdb"
+  }
+}
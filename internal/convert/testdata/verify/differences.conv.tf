@@ -0,0 +1,84 @@
+resource "mongodbatlas_advanced_cluster" "wrong_num_shards" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+  replication_specs = [
+    {
+      zone_name = "Zone 1"
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M30"
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "dropped_region" {
+  project_id   = var.project_id
+  name         = "multi-region"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "US_EAST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "variable_region" {
+  project_id   = var.project_id
+  name         = "variable-region"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = var.region
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M10"
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "mongodbatlas_advanced_cluster" "resized" {
+  project_id   = var.project_id
+  name         = "resized"
+  cluster_type = "REPLICASET"
+  replication_specs = [
+    {
+      region_configs = [
+        {
+          provider_name = "AWS"
+          region_name   = "EU_WEST_1"
+          priority      = 7
+          electable_specs = {
+            node_count    = 3
+            instance_size = "M20"
+          }
+        }
+      ]
+    }
+  ]
+}
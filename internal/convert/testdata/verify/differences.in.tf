@@ -0,0 +1,80 @@
+resource "mongodbatlas_cluster" "wrong_num_shards" {
+  project_id   = var.project_id
+  name         = "geo"
+  cluster_type = "GEOSHARDED"
+
+  provider_name               = "AWS"
+  provider_instance_size_name = "M30"
+
+  replication_specs {
+    zone_name  = "Zone 1"
+    num_shards = 2
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "dropped_region" {
+  project_id   = var.project_id
+  name         = "multi-region"
+  cluster_type = "REPLICASET"
+
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+    regions_config {
+      region_name     = "US_WEST_2"
+      electable_nodes = 0
+      read_only_nodes = 1
+      priority        = 0
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "not_converted" {
+  project_id                  = var.project_id
+  name                        = "not-converted"
+  provider_name               = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_cluster" "variable_region" {
+  project_id                  = var.project_id
+  name                        = "variable-region"
+  provider_name               = "AWS"
+  provider_region_name        = var.region
+  provider_instance_size_name = "M10"
+}
+
+resource "mongodbatlas_advanced_cluster" "resized" {
+  project_id   = var.project_id
+  name         = "resized"
+  cluster_type = "REPLICASET"
+
+  replication_specs {
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "EU_WEST_1"
+      priority      = 7
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 3
+      }
+      analytics_specs {
+        instance_size = "M10"
+        node_count    = 1
+      }
+    }
+  }
+}
@@ -0,0 +1,169 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// clusterTopology is a normalized model of the deployment of a cluster, used to check that two cluster
+// definitions, e.g. before and after a conversion, deploy the same nodes.
+type clusterTopology struct {
+	ClusterType string
+	Shards      []shardTopology
+}
+
+type shardTopology struct {
+	ZoneName string
+	Regions  []regionTopology
+}
+
+type regionTopology struct {
+	ProviderName string
+	RegionName   string
+	Nodes        map[string]nodesTopology // key is the spec name, e.g. electable_specs
+	Priority     int
+}
+
+type nodesTopology struct {
+	InstanceSize string
+	NodeCount    int
+}
+
+// getAdvClusterTopology returns the topology of mongodbatlas_advanced_cluster attributes in a state or plan.
+// Both provider 2.0.0 nested objects and 1.X.X lists are supported, shards are expanded if num_shards is set.
+func getAdvClusterTopology(attrs attrMap) clusterTopology {
+	ret := clusterTopology{ClusterType: getString(attrs[nClusterType])}
+	if ret.ClusterType == "" {
+		ret.ClusterType = valClusterType // default cluster type if not set
+	}
+	for _, specSrc := range getList(attrs, nRepSpecs) {
+		shard := shardTopology{ZoneName: getString(specSrc[nZoneName])}
+		for _, configSrc := range getList(specSrc, nConfig) {
+			region := regionTopology{
+				ProviderName: getString(configSrc[nProviderName]),
+				RegionName:   getAtlasRegionName(configSrc),
+				Priority:     getInt(configSrc[nPriority]),
+				Nodes:        make(map[string]nodesTopology),
+			}
+			for _, name := range specsWithDisk {
+				spec := getObject(configSrc, name)
+				nodes := nodesTopology{InstanceSize: getString(spec[nInstanceSize]), NodeCount: getInt(spec[nNodeCount])}
+				if region.ProviderName == nTenant && nodes.InstanceSize != "" {
+					nodes.NodeCount = 0 // node count is not configurable in tenant clusters
+					region.Nodes[name] = nodes
+				} else if nodes.NodeCount > 0 {
+					region.Nodes[name] = nodes
+				}
+			}
+			shard.Regions = append(shard.Regions, region)
+		}
+		sortRegionsTopology(shard.Regions)
+		for range max(getInt(specSrc[nNumShards]), 1) {
+			ret.Shards = append(ret.Shards, shard)
+		}
+	}
+	return ret
+}
+
+// getClusterTopology returns the topology of mongodbatlas_cluster attributes in a state or plan.
+func getClusterTopology(attrs attrMap) clusterTopology {
+	return getAdvClusterTopology(migrateClusterAttrs(attrs))
+}
+
+// diffTopology returns the human-readable differences between two topologies, nil if they're equivalent.
+func diffTopology(before, after clusterTopology) []string {
+	var diffs []string
+	if before.ClusterType != after.ClusterType {
+		diffs = append(diffs, fmt.Sprintf("%s changes from %q to %q", nClusterType, before.ClusterType, after.ClusterType))
+	}
+	if len(before.Shards) != len(after.Shards) {
+		diffs = append(diffs, fmt.Sprintf("number of shards changes from %d to %d", len(before.Shards), len(after.Shards)))
+	}
+	for i := range min(len(before.Shards), len(after.Shards)) {
+		shardBefore, shardAfter := before.Shards[i], after.Shards[i]
+		if shardBefore.ZoneName != shardAfter.ZoneName {
+			diffs = append(diffs, fmt.Sprintf("shard %d: %s changes from %q to %q",
+				i, nZoneName, shardBefore.ZoneName, shardAfter.ZoneName))
+		}
+		regionsBefore, regionsAfter := regionsByKey(shardBefore.Regions), regionsByKey(shardAfter.Regions)
+		for _, region := range shardBefore.Regions {
+			key := region.key()
+			regionAfter, found := regionsAfter[key]
+			if !found {
+				diffs = append(diffs, fmt.Sprintf("shard %d: region %s is removed", i, key))
+				continue
+			}
+			diffs = append(diffs, diffRegion(fmt.Sprintf("shard %d: region %s", i, key), region, regionAfter)...)
+		}
+		for _, region := range shardAfter.Regions {
+			if _, found := regionsBefore[region.key()]; !found {
+				diffs = append(diffs, fmt.Sprintf("shard %d: region %s is added", i, region.key()))
+			}
+		}
+	}
+	return diffs
+}
+
+func diffRegion(prefix string, before, after regionTopology) []string {
+	var diffs []string
+	if before.Priority != after.Priority {
+		diffs = append(diffs, fmt.Sprintf("%s: %s changes from %d to %d", prefix, nPriority, before.Priority, after.Priority))
+	}
+	for _, name := range specsWithDisk {
+		nodesBefore, nodesAfter := before.Nodes[name], after.Nodes[name]
+		if nodesBefore != nodesAfter {
+			diffs = append(diffs, fmt.Sprintf("%s: %s changes from %s to %s",
+				prefix, name, nodesBefore.String(), nodesAfter.String()))
+		}
+	}
+	return diffs
+}
+
+func (r *regionTopology) key() string {
+	return r.ProviderName + "/" + r.RegionName
+}
+
+func (n nodesTopology) String() string {
+	switch {
+	case n.InstanceSize == "" && n.NodeCount == 0:
+		return "no nodes"
+	case n.NodeCount == 0:
+		return n.InstanceSize
+	}
+	return fmt.Sprintf("%d x %s", n.NodeCount, n.InstanceSize)
+}
+
+func regionsByKey(regions []regionTopology) map[string]regionTopology {
+	ret := make(map[string]regionTopology, len(regions))
+	for _, region := range regions {
+		ret[region.key()] = region
+	}
+	return ret
+}
+
+// sortRegionsTopology sorts regions by descending priority and then by key so order doesn't cause differences.
+func sortRegionsTopology(regions []regionTopology) {
+	sort.SliceStable(regions, func(i, j int) bool {
+		if regions[i].Priority != regions[j].Priority {
+			return regions[i].Priority > regions[j].Priority
+		}
+		return strings.Compare(regions[i].key(), regions[j].key()) < 0
+	})
+}
+
+// getObject returns a nested object attribute, both as an object or as a list of one object (SDKv2 blocks).
+func getObject(src attrMap, name string) attrMap {
+	if obj, ok := src[name].(map[string]any); ok {
+		return obj
+	}
+	if list := getList(src, name); len(list) > 0 {
+		return list[0]
+	}
+	return nil
+}
+
+func getString(val any) string {
+	str, _ := val.(string)
+	return str
+}
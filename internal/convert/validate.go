@@ -0,0 +1,263 @@
+package convert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyconvert "github.com/zclconf/go-cty/cty/convert"
+)
+
+// metaArguments are the root attributes of resources and data sources defined by Terraform.
+var metaArguments = []string{nCount, nForEach, "provider", "depends_on"}
+
+// SchemaError is an error found validating a converted configuration against the provider schema.
+type SchemaError struct {
+	Address string // resource or data source, e.g. mongodbatlas_advanced_cluster.cluster
+	Message string
+	Range   hcl.Range
+}
+
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("%s:%d,%d: %s: %s", e.Range.Filename, e.Range.Start.Line, e.Range.Start.Column,
+		e.Address, e.Message)
+}
+
+// SchemaReport is the result of validating a converted configuration against the provider schema.
+type SchemaReport struct {
+	Errors       []SchemaError // errors found, they're conversion errors
+	NotValidated []string      // changed mongodbatlas resources and data sources whose type is not in the snapshot
+}
+
+// ValidateSchema checks the resources and data sources changed by a conversion against the embedded snapshot of
+// the provider schema: attribute and block names, blocks used as nested attributes or the other way around,
+// read-only attributes, and the type of literal values. Expressions that can't be evaluated statically are not
+// checked. Resources and data sources not changed by the conversion are skipped, as well as the ones not in the
+// snapshot, which are reported as not validated if they're mongodbatlas types.
+// filename is only used in the error positions.
+func ValidateSchema(config, converted []byte, filename string) (SchemaReport, error) {
+	var report SchemaReport
+	original, err := getBlockContents(config, "")
+	if err != nil {
+		return report, err
+	}
+	file, diags := hclsyntax.ParseConfig(converted, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return report, fmt.Errorf("failed to parse converted config: %s", diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return report, fmt.Errorf("failed to parse converted config: unexpected body type %T", file.Body)
+	}
+	for _, block := range body.Blocks {
+		address := getSyntaxBlockAddress(block)
+		if slices.Contains(original[address], string(block.Range().SliceBytes(converted))) {
+			continue
+		}
+		blockSchema := getValidationSchema(block)
+		if blockSchema == nil {
+			if len(block.Labels) > 0 && strings.HasPrefix(block.Labels[0], providerTypePrefix) {
+				report.NotValidated = append(report.NotValidated,
+					fmt.Sprintf("%s: %s is not in the provider schema snapshot", address, block.Labels[0]))
+			}
+			continue
+		}
+		v := &schemaValidator{address: address}
+		v.validateBody(block.Body, blockSchema.Attributes, blockSchema.BlockTypes, "", true)
+		slices.SortStableFunc(v.errors, func(a, b SchemaError) int { return a.Range.Start.Byte - b.Range.Start.Byte })
+		report.Errors = append(report.Errors, v.errors...)
+	}
+	return report, nil
+}
+
+// getBlockContents returns the contents of the resources and data sources in a configuration by address.
+func getBlockContents(config []byte, filename string) (map[string][]string, error) {
+	file, diags := hclsyntax.ParseConfig(config, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse Terraform config file: %s", diags.Error())
+	}
+	ret := make(map[string][]string)
+	if body, ok := file.Body.(*hclsyntax.Body); ok {
+		for _, block := range body.Blocks {
+			address := getSyntaxBlockAddress(block)
+			ret[address] = append(ret[address], string(block.Range().SliceBytes(config)))
+		}
+	}
+	return ret, nil
+}
+
+func getValidationSchema(block *hclsyntax.Block) *schema.Block {
+	if len(block.Labels) != 2 { //nolint:mnd // type and name labels
+		return nil
+	}
+	switch block.Type {
+	case resourceType:
+		return schema.Resource(block.Labels[0])
+	case dataSourceType:
+		return schema.DataSource(block.Labels[0])
+	}
+	return nil
+}
+
+// getSyntaxBlockAddress is like getBlockAddress for parsed blocks.
+func getSyntaxBlockAddress(block *hclsyntax.Block) string {
+	switch block.Type {
+	case resourceType:
+		return joinPath(block.Labels...)
+	case dataSourceType:
+		return joinPath(append([]string{dataSourceType}, block.Labels...)...)
+	}
+	return block.Type
+}
+
+type schemaValidator struct {
+	address string
+	errors  []SchemaError
+}
+
+func (v *schemaValidator) addError(rng hcl.Range, format string, args ...any) {
+	v.errors = append(v.errors, SchemaError{Address: v.address, Message: fmt.Sprintf(format, args...), Range: rng})
+}
+
+// validateBody validates the attributes and blocks of a body, path is the path of the body in the resource,
+// e.g. replication_specs.region_configs. Meta-arguments like count or lifecycle are only allowed at the root.
+func (v *schemaValidator) validateBody(body *hclsyntax.Body, attrs map[string]*schema.Attribute,
+	blockTypes map[string]*schema.BlockType, path string, isRoot bool) {
+	for _, attr := range body.Attributes { // errors are sorted by position at the end
+		if isRoot && slices.Contains(metaArguments, attr.Name) {
+			continue
+		}
+		attrPath := joinPath(path, attr.Name)
+		if attrs[attr.Name] == nil && blockTypes[attr.Name] != nil {
+			v.addError(attr.NameRange, "%s must be a block, not an attribute", attrPath)
+			continue
+		}
+		v.validateAttr(attr.Expr, attr.NameRange, attrs[attr.Name], attrPath)
+	}
+	for _, block := range body.Blocks {
+		if isRoot && slices.Contains(metaBlocks, block.Type) {
+			continue
+		}
+		name, content, rng := block.Type, block.Body, block.TypeRange
+		if block.Type == nDynamic && len(block.Labels) > 0 {
+			name, content, rng = block.Labels[0], nil, block.LabelRanges[0]
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == nContent {
+					content = nested.Body
+				}
+			}
+		}
+		blockPath := joinPath(path, name)
+		switch blockType := blockTypes[name]; {
+		case blockType != nil && blockType.Block != nil:
+			if content != nil {
+				v.validateBody(content, blockType.Block.Attributes, blockType.Block.BlockTypes, blockPath, false)
+			}
+		case attrs[name] != nil:
+			v.addError(rng, "%s must be an attribute, not a block", blockPath)
+		default:
+			v.addError(rng, "unsupported block %s", blockPath)
+		}
+	}
+}
+
+// validateAttr validates the value of an attribute or an object item, attr is nil if it's not in the schema.
+func (v *schemaValidator) validateAttr(expr hclsyntax.Expression, rng hcl.Range, attr *schema.Attribute, path string) {
+	switch {
+	case attr == nil:
+		v.addError(rng, "unsupported attribute %s", path)
+	case attr.Computed && !attr.Optional && !attr.Required:
+		v.addError(rng, "%s is read-only and can't be set", path)
+	case attr.NestedType != nil:
+		v.validateNested(expr, attr.NestedType, path)
+	default:
+		v.validateLiteral(expr, attr, path)
+	}
+}
+
+// validateNested validates the value of a nested attribute, only object and tuple constructors and for expressions
+// are checked, other expressions like references or function calls can't be validated statically.
+func (v *schemaValidator) validateNested(expr hclsyntax.Expression, nested *schema.NestedType, path string) {
+	switch nested.NestingMode {
+	case schema.NestingSingle:
+		v.validateObject(expr, nested.Attributes, path)
+	case schema.NestingList, schema.NestingSet:
+		switch e := expr.(type) {
+		case *hclsyntax.TupleConsExpr:
+			for _, item := range e.Exprs {
+				v.validateObject(item, nested.Attributes, path)
+			}
+		case *hclsyntax.ForExpr:
+			if e.KeyExpr != nil {
+				v.addError(e.Range(), "%s must be a list of objects, not a map", path)
+				return
+			}
+			v.validateObject(e.ValExpr, nested.Attributes, path)
+		case *hclsyntax.ObjectConsExpr, *hclsyntax.TemplateExpr, *hclsyntax.LiteralValueExpr:
+			v.addError(e.Range(), "%s must be a list of objects", path)
+		}
+	case schema.NestingMap:
+		switch e := expr.(type) {
+		case *hclsyntax.ObjectConsExpr:
+			for _, item := range e.Items {
+				v.validateObject(item.ValueExpr, nested.Attributes, path)
+			}
+		case *hclsyntax.TupleConsExpr, *hclsyntax.TemplateExpr, *hclsyntax.LiteralValueExpr:
+			v.addError(e.Range(), "%s must be a map of objects", path)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(expr hclsyntax.Expression, attrs map[string]*schema.Attribute, path string) {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			name := hcl.ExprAsKeyword(item.KeyExpr)
+			if name == "" {
+				key, diags := item.KeyExpr.Value(nil)
+				if diags.HasErrors() || !key.IsKnown() || key.IsNull() || key.Type() != cty.String {
+					continue // key can't be evaluated statically
+				}
+				name = key.AsString()
+			}
+			v.validateAttr(item.ValueExpr, item.KeyExpr.Range(), attrs[name], joinPath(path, name))
+		}
+	case *hclsyntax.TupleConsExpr, *hclsyntax.TemplateExpr, *hclsyntax.LiteralValueExpr:
+		v.addError(e.Range(), "%s must be an object", path)
+	}
+}
+
+// validateLiteral checks that the value of an expression without variables or function calls can be converted to
+// the attribute type, e.g. "M10" can't be used in a number attribute.
+func (v *schemaValidator) validateLiteral(expr hclsyntax.Expression, attr *schema.Attribute, path string) {
+	var ty cty.Type
+	if len(attr.Type) == 0 || ty.UnmarshalJSON(attr.Type) != nil || len(expr.Variables()) > 0 {
+		return
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return
+	}
+	if _, err := ctyconvert.Convert(val, ty); err != nil {
+		v.addError(expr.Range(), "invalid value for %s: %s", path, err)
+	}
+}
+
+// joinPath joins the non-empty parts of a path with dots.
+func joinPath(parts ...string) string {
+	var ret string
+	for _, part := range parts {
+		switch {
+		case part == "":
+		case ret == "":
+			ret = part
+		default:
+			ret += "." + part
+		}
+	}
+	return ret
+}
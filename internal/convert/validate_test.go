@@ -0,0 +1,31 @@
+package convert_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateSchema checks the schema errors of a converted configuration (suffix .conv.tf) changed from the
+// original configuration (suffix .in.tf).
+func TestValidateSchema(t *testing.T) {
+	const cmdName = "validate"
+	runConvertTestsWithSuffix(t, cmdName, ".in.tf", ".out.txt", func(testName string, inConfig []byte) ([]byte, error) {
+		filename := testName + ".conv.tf"
+		outConfig, err := afero.ReadFile(afero.NewOsFs(), filepath.Join("testdata", cmdName, filename))
+		require.NoError(t, err)
+		report, err := convert.ValidateSchema(inConfig, outConfig, filename)
+		var sb strings.Builder
+		for _, schemaError := range report.Errors {
+			sb.WriteString(schemaError.Error() + "\n")
+		}
+		for _, notValidated := range report.NotValidated {
+			sb.WriteString("not validated: " + notValidated + "\n")
+		}
+		return []byte(sb.String()), err
+	})
+}
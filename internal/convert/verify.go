@@ -0,0 +1,197 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+var (
+	// clusterTopologyAttrs are the mongodbatlas_cluster root attributes and blocks that define the topology.
+	clusterTopologyAttrs = []string{
+		nProviderName, nBackingProviderName, nInstanceSizeSrc, nRegionNameSrc, nReplicationFactorSrc,
+		nClusterType, nRepSpecs,
+	}
+	// advClusterTopologyAttrs are the mongodbatlas_advanced_cluster root attributes and blocks that define the topology.
+	advClusterTopologyAttrs = []string{nClusterType, nRepSpecs}
+
+	// verifyEvalContext has the functions used in converted configurations, e.g. range in compacted shards.
+	verifyEvalContext = &hcl.EvalContext{Functions: map[string]function.Function{
+		"range":   stdlib.RangeFunc,
+		"concat":  stdlib.ConcatFunc,
+		"flatten": stdlib.FlattenFunc,
+		"merge":   stdlib.MergeFunc,
+		"lookup":  stdlib.LookupFunc,
+		"length":  stdlib.LengthFunc,
+	}}
+)
+
+// TopologyReport is the result of verifying that the clusters in a converted configuration have the same
+// topology as in the original configuration.
+type TopologyReport struct {
+	Differences []string // differences found, they're conversion errors
+	NotVerified []string // clusters that can't be evaluated statically, e.g. because they use variables
+}
+
+// VerifyTopology evaluates the mongodbatlas_cluster and mongodbatlas_advanced_cluster resources in the original
+// configuration and the mongodbatlas_advanced_cluster resources with the same name in the converted configuration
+// into a normalized topology: shards, zones, regions, provider, priority, and node count and instance size
+// per node type, and reports any difference between them.
+// Only literal values and simple functions can be evaluated, other clusters are reported as not verified.
+func VerifyTopology(inConfig, outConfig []byte) (TopologyReport, error) {
+	var report TopologyReport
+	inResources, err := getResourceBodies(inConfig)
+	if err != nil {
+		return report, err
+	}
+	outResources, err := getResourceBodies(outConfig)
+	if err != nil {
+		return report, err
+	}
+	occurrences := make(map[string]int) // resources with the same name are matched in order
+	for _, block := range inResources {
+		resType, label := block.Labels[0], block.Labels[1]
+		if resType != cluster && resType != advCluster {
+			continue
+		}
+		address := fmt.Sprintf("%s.%s", advCluster, label)
+		outBlock := findResourceBody(outResources, advCluster, label, occurrences[label])
+		occurrences[label]++
+		if outBlock == nil && findResourceBody(outResources, flexCluster, label, 0) != nil {
+			report.NotVerified = append(report.NotVerified, fmt.Sprintf("%s.%s: converted to %s, topology is not compared",
+				cluster, label, flexCluster))
+			continue
+		}
+		if outBlock == nil {
+			report.Differences = append(report.Differences, address+": not found in converted configuration")
+			continue
+		}
+		before, err := getConfigTopology(block)
+		if err == nil {
+			var after clusterTopology
+			if after, err = getConfigTopology(outBlock); err == nil {
+				for _, diff := range diffTopology(before, after) {
+					report.Differences = append(report.Differences, fmt.Sprintf("%s: %s", address, diff))
+				}
+				continue
+			}
+		}
+		report.NotVerified = append(report.NotVerified, fmt.Sprintf("%s: %s", address, err))
+	}
+	return report, nil
+}
+
+// getConfigTopology returns the topology of a cluster resource in a configuration file.
+func getConfigTopology(block *hclsyntax.Block) (clusterTopology, error) {
+	if block.Labels[0] == cluster {
+		attrs, err := evalBody(block.Body, clusterTopologyAttrs)
+		if err != nil {
+			return clusterTopology{}, err
+		}
+		return getClusterTopology(attrs), nil
+	}
+	attrs, err := evalBody(block.Body, advClusterTopologyAttrs)
+	if err != nil {
+		return clusterTopology{}, err
+	}
+	return getAdvClusterTopology(attrs), nil
+}
+
+func getResourceBodies(config []byte) ([]*hclsyntax.Block, error) {
+	file, diags := hclsyntax.ParseConfig(config, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse Terraform config file: %s", diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse Terraform config file: unexpected body type")
+	}
+	var ret []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		if block.Type == resourceType && len(block.Labels) == 2 {
+			ret = append(ret, block)
+		}
+	}
+	return ret, nil
+}
+
+// findResourceBody returns the n-th resource (starting from 0) with the given type and name.
+func findResourceBody(blocks []*hclsyntax.Block, resType, label string, n int) *hclsyntax.Block {
+	for _, block := range blocks {
+		if block.Labels[0] == resType && block.Labels[1] == label {
+			if n == 0 {
+				return block
+			}
+			n--
+		}
+	}
+	return nil
+}
+
+// evalBody evaluates the attributes and blocks of a body in the same shape as in state, blocks are lists of objects.
+// If names is not nil, only those root attributes and blocks are evaluated.
+func evalBody(body *hclsyntax.Body, names []string) (attrMap, error) {
+	ret := attrMap{}
+	for name, attr := range body.Attributes {
+		if names != nil && !slices.Contains(names, name) {
+			continue
+		}
+		val, diags := attr.Expr.Value(verifyEvalContext)
+		if diags.HasErrors() || !val.IsWhollyKnown() {
+			return nil, fmt.Errorf("attribute %s can't be evaluated statically", name)
+		}
+		ret[name] = ctyToAny(val)
+	}
+	for _, block := range body.Blocks {
+		if block.Type == nDynamic {
+			if len(block.Labels) == 1 && (names == nil || slices.Contains(names, block.Labels[0])) {
+				return nil, fmt.Errorf("dynamic block %s can't be evaluated statically", block.Labels[0])
+			}
+			continue
+		}
+		if names != nil && !slices.Contains(names, block.Type) {
+			continue
+		}
+		obj, err := evalBody(block.Body, nil)
+		if err != nil {
+			return nil, err
+		}
+		list, _ := ret[block.Type].([]any)
+		ret[block.Type] = append(list, obj)
+	}
+	return ret, nil
+}
+
+// ctyToAny converts a cty value to the same Go types used in state attributes.
+func ctyToAny(val cty.Value) any {
+	switch {
+	case val.IsNull():
+		return nil
+	case val.Type() == cty.String:
+		return val.AsString()
+	case val.Type() == cty.Bool:
+		return val.True()
+	case val.Type() == cty.Number:
+		num, _ := val.AsBigFloat().Int(new(big.Int))
+		return int(num.Int64())
+	case val.CanIterateElements() && (val.Type().IsObjectType() || val.Type().IsMapType()):
+		ret := map[string]any{}
+		for k, v := range val.AsValueMap() {
+			ret[k] = ctyToAny(v)
+		}
+		return ret
+	case val.CanIterateElements():
+		ret := []any{}
+		for _, v := range val.AsValueSlice() {
+			ret = append(ret, ctyToAny(v))
+		}
+		return ret
+	}
+	return nil
+}
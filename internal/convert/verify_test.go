@@ -0,0 +1,58 @@
+package convert_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyTopology checks the differences between the original configuration (suffix .in.tf)
+// and a converted configuration with errors (suffix .conv.tf).
+func TestVerifyTopology(t *testing.T) {
+	const cmdName = "verify"
+	runConvertTestsWithSuffix(t, cmdName, ".in.tf", ".out.txt", func(testName string, inConfig []byte) ([]byte, error) {
+		outConfig, err := afero.ReadFile(afero.NewOsFs(), filepath.Join("testdata", cmdName, testName+".conv.tf"))
+		require.NoError(t, err)
+		report, err := convert.VerifyTopology(inConfig, outConfig)
+		var sb strings.Builder
+		for _, diff := range report.Differences {
+			sb.WriteString("difference: " + diff + "\n")
+		}
+		for _, notVerified := range report.NotVerified {
+			sb.WriteString("not verified: " + notVerified + "\n")
+		}
+		return []byte(sb.String()), err
+	})
+}
+
+// TestVerifyTopologyGoldenFiles checks that the clusters in all golden files have the same topology
+// as in their input files, so regressions can be caught even if golden files are updated by mistake.
+func TestVerifyTopologyGoldenFiles(t *testing.T) {
+	fs := afero.NewOsFs()
+	for i := range convert.Converters {
+		if !convert.Converters[i].Verify {
+			continue
+		}
+		cmdName := convert.Converters[i].Aliases[0]
+		outputFiles, err := afero.Glob(fs, filepath.Join("testdata", cmdName, "*.out.tf"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, outputFiles)
+		for _, outputFile := range outputFiles {
+			testName := strings.TrimSuffix(filepath.Base(outputFile), ".out.tf")
+			t.Run(cmdName+"/"+testName, func(t *testing.T) {
+				inConfig, err := afero.ReadFile(fs, strings.TrimSuffix(outputFile, ".out.tf")+".in.tf")
+				require.NoError(t, err)
+				outConfig, err := afero.ReadFile(fs, outputFile)
+				require.NoError(t, err)
+				report, err := convert.VerifyTopology(inConfig, outConfig)
+				require.NoError(t, err)
+				assert.Empty(t, report.Differences)
+			})
+		}
+	}
+}
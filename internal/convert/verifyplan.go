@@ -0,0 +1,142 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	actionCreate  = "create"
+	actionDelete  = "delete"
+	actionUpdate  = "update"
+	actionReplace = "replace"
+)
+
+// PlanIssue is an unsafe change to a cluster found in a Terraform plan.
+type PlanIssue struct {
+	Address string
+	Action  string
+	Details []string
+}
+
+type tfPlan struct {
+	FormatVersion   string             `json:"format_version"`
+	ResourceChanges []tfResourceChange `json:"resource_changes"`
+}
+
+type tfResourceChange struct {
+	Address         string   `json:"address"`
+	PreviousAddress string   `json:"previous_address"`
+	Mode            string   `json:"mode"`
+	Type            string   `json:"type"`
+	Change          tfChange `json:"change"`
+}
+
+type tfChange struct {
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	AfterUnknown json.RawMessage `json:"after_unknown"`
+	Actions      []string        `json:"actions"`
+}
+
+// VerifyPlan checks the output of terraform show -json for a plan file and returns the changes that would modify
+// the clusters deployed in MongoDB Atlas: deletions, replacements and in-place updates that change the topology,
+// e.g. node counts, instance sizes or regions. Conversions are expected to produce plans without any of them.
+func VerifyPlan(planContent []byte) ([]PlanIssue, error) {
+	var plan tfPlan
+	if err := json.Unmarshal(planContent, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse Terraform plan file: %w", err)
+	}
+	if plan.FormatVersion == "" {
+		return nil, fmt.Errorf("failed to parse Terraform plan file: format_version not found, " +
+			"use the output of terraform show -json")
+	}
+	var issues []PlanIssue
+	for i := range plan.ResourceChanges {
+		change := &plan.ResourceChanges[i]
+		if change.Mode != stateModeManaged || (change.Type != cluster && change.Type != advCluster) {
+			continue
+		}
+		issue, err := verifyResourceChange(change)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues, nil
+}
+
+func (i *PlanIssue) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s", i.Address, i.Action)
+	for _, detail := range i.Details {
+		sb.WriteString("\n  - " + detail)
+	}
+	return sb.String()
+}
+
+func verifyResourceChange(change *tfResourceChange) (*PlanIssue, error) {
+	actions := change.Change.Actions
+	issue := &PlanIssue{Address: change.Address}
+	if change.PreviousAddress != "" {
+		issue.Details = append(issue.Details, "moved from "+change.PreviousAddress)
+	}
+	switch {
+	case slices.Contains(actions, actionDelete) && slices.Contains(actions, actionCreate):
+		issue.Action = actionReplace
+		return issue, nil
+	case slices.Contains(actions, actionDelete):
+		issue.Action = actionDelete
+		return issue, nil
+	case !slices.Contains(actions, actionUpdate):
+		return nil, nil
+	}
+	issue.Action = actionUpdate
+	afterUnknown, err := decodeAttrs(change.Change.AfterUnknown)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", change.Address, err)
+	}
+	if hasTrueValue(afterUnknown[nRepSpecs]) || hasTrueValue(afterUnknown[nClusterType]) {
+		issue.Details = append(issue.Details, "topology is unknown until apply")
+		return issue, nil
+	}
+	before, err := decodeAttrs(change.Change.Before)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", change.Address, err)
+	}
+	after, err := decodeAttrs(change.Change.After)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", change.Address, err)
+	}
+	getTopology := getAdvClusterTopology
+	if change.Type == cluster {
+		getTopology = getClusterTopology
+	}
+	diffs := diffTopology(getTopology(before), getTopology(after))
+	if len(diffs) == 0 {
+		return nil, nil // in-place update that doesn't change the topology, e.g. tags
+	}
+	issue.Details = append(issue.Details, diffs...)
+	return issue, nil
+}
+
+// hasTrueValue returns true if the value or any nested value is true, e.g. in after_unknown.
+func hasTrueValue(val any) bool {
+	switch v := val.(type) {
+	case bool:
+		return v
+	case []any:
+		return slices.ContainsFunc(v, hasTrueValue)
+	case map[string]any:
+		for _, item := range v {
+			if hasTrueValue(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+)
+
+func TestVerifyPlan(t *testing.T) {
+	runConvertTestsWithSuffix(t, "verifyplan", ".in.json", ".out.txt",
+		func(testName string, inConfig []byte) ([]byte, error) {
+			issues, err := convert.VerifyPlan(inConfig)
+			var sb strings.Builder
+			for i := range issues {
+				sb.WriteString(issues[i].String() + "\n")
+			}
+			return []byte(sb.String()), err
+		})
+}
@@ -0,0 +1,107 @@
+package convert
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/hcl"
+)
+
+var (
+	// infoComments are the comments added by the conversions that don't require any action.
+	infoComments = []string{
+		commentGeneratedBy, commentConfirmReferences, commentUpdatedBy, commentMovedBlock, commentRemovedOld,
+		commentPriorityFor, commentRemovedBlock, commentRemovedNote, commentImportBlock, commentImportNote,
+		commentCheckBlock, commentCheckNote, commentRuleMovedNote,
+	}
+	// infoCommentPrefix is the prefix of the comments added to blocks whose references were updated.
+	infoCommentPrefix = "Updated by atlas-cli-plugin-terraform"
+	// fileWarnings are the warnings added out of blocks, e.g. when an import block is not generated.
+	fileWarnings = []string{
+		commentImportNotFound, commentImportCount, commentRemovedNotGenerated, commentImportUnknown, commentCheckNotGenerated,
+	}
+)
+
+// Warning is a comment added by a conversion that requires a review, e.g. an attribute that was removed.
+type Warning struct {
+	Address string `json:"address"` // block where the warning was added, e.g. mongodbatlas_advanced_cluster.cluster
+	Message string `json:"message"`
+}
+
+// CollectWarnings returns the warnings added by a conversion, comparing the converted file with the original one.
+// Comments in the original file and informational comments like "Generated by atlas-cli-plugin-terraform" are
+// not warnings.
+func CollectWarnings(config, converted []byte) ([]Warning, error) {
+	original, err := hcl.GetParser(config)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := hcl.GetParser(converted)
+	if err != nil {
+		return nil, err
+	}
+	originalComments := make(map[string]int)
+	for _, token := range original.BuildTokens(nil) {
+		if token.Type == hclsyntax.TokenComment {
+			originalComments[getCommentText(token)]++
+		}
+	}
+	addresses := make(map[*hclwrite.Token]string)
+	for _, block := range parser.Body().Blocks() {
+		address := getBlockAddress(block)
+		for _, token := range block.BuildTokens(nil) {
+			addresses[token] = address
+		}
+	}
+	var ret []Warning
+	for _, token := range parser.BuildTokens(nil) {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+		text := getCommentText(token)
+		if originalComments[text] > 0 {
+			originalComments[text]--
+			continue
+		}
+		address, inBlock := addresses[token]
+		if isWarningComment(text, inBlock) {
+			ret = append(ret, Warning{Address: address, Message: text})
+		}
+	}
+	return ret, nil
+}
+
+// isWarningComment returns true if a comment added by a conversion is a warning. Only some known warnings are
+// added out of blocks, other comments there are headers or commented out code, e.g. with keepOriginal option.
+func isWarningComment(text string, inBlock bool) bool {
+	if !inBlock {
+		return slices.ContainsFunc(fileWarnings, func(format string) bool {
+			prefix, _, _ := strings.Cut(format, "%")
+			return strings.HasPrefix(text, prefix)
+		})
+	}
+	return text != "" && !slices.Contains(infoComments, text) && !strings.HasPrefix(text, infoCommentPrefix)
+}
+
+// getBlockAddress returns the address of a block, e.g. mongodbatlas_advanced_cluster.cluster or
+// data.mongodbatlas_advanced_cluster.cluster. Other blocks like terraform or locals return their type.
+func getBlockAddress(block *hclwrite.Block) string {
+	switch block.Type() {
+	case resourceType:
+		return strings.Join(block.Labels(), ".")
+	case dataSourceType:
+		return strings.Join(append([]string{dataSourceType}, block.Labels()...), ".")
+	}
+	return block.Type()
+}
+
+func getCommentText(token *hclwrite.Token) string {
+	text := strings.TrimSpace(string(token.Bytes))
+	text = strings.TrimPrefix(strings.TrimSuffix(text, "*/"), "/*")
+	for _, prefix := range []string{"#", "//"} {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	return strings.TrimSpace(text)
+}
@@ -11,4 +11,24 @@ const (
 	WatchShort         = "w"
 	IncludeMoved       = "includeMoved"
 	IncludeMovedShort  = "m"
+	CompactShards      = "compactShards"
+	CompactShardsShort = "c"
+	ExtractLocals      = "extractLocals"
+	ExtractLocalsShort = "l"
+	KeepOriginal       = "keepOriginal"
+	KeepOriginalShort  = "k"
+	IncludeRemoved     = "includeRemoved"
+	IncludeImport      = "includeImport"
+	IncludeImportShort = "i"
+	StateScript        = "stateScript"
+	StateScriptShort   = "s"
+	ModulePath         = "modulePath"
+	Verify             = "verify"
+	State              = "state"
+	IncludeCheck       = "includeCheck"
+	ConvertFlex        = "convertFlex"
+	UpdateProvider     = "updateProvider"
+	Rules              = "rules"
+	Validate           = "validate"
+	NormalizeRegions   = "normalizeRegions"
 )
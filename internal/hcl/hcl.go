@@ -1,6 +1,7 @@
 package hcl
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
 	"strings"
@@ -64,6 +65,15 @@ func GetAttrInt(attr *hclwrite.Attribute, errPrefix string) (int, error) {
 	return int(num), nil
 }
 
+// ParseAttrExpr parses the expression of an attribute so it can be inspected.
+func ParseAttrExpr(attr *hclwrite.Attribute) (hclsyntax.Expression, error) {
+	expr, diags := hclsyntax.ParseExpression(attr.Expr().BuildTokens(nil).Bytes(), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse expression: %s", diags.Error())
+	}
+	return expr, nil
+}
+
 // GetAttrString tries to get an attribute value as a string.
 func GetAttrString(attr *hclwrite.Attribute) (string, error) {
 	expr, diags := hclsyntax.ParseExpression(attr.Expr().BuildTokens(nil).Bytes(), "", hcl.InitialPos)
@@ -80,6 +90,27 @@ func GetAttrString(attr *hclwrite.Attribute) (string, error) {
 	return val.AsString(), nil
 }
 
+// GetAttrTemplatePart returns the attribute value so it can be used inside a string template,
+// e.g. "name" is returned as name and var.name as ${var.name}. It returns "" if the attribute is not set.
+// Literal values are escaped, the result must be enclosed in quotes.
+func GetAttrTemplatePart(attr *hclwrite.Attribute) string {
+	if attr == nil {
+		return ""
+	}
+	if str, err := GetAttrString(attr); err == nil {
+		quoted := strconv.Quote(str)
+		quoted = quoted[1 : len(quoted)-1] // remove enclosing quotes
+		return strings.ReplaceAll(strings.ReplaceAll(quoted, "${", "$${"), "%{", "%%{")
+	}
+	exprStr := GetAttrExpr(attr)
+	if expr, err := ParseAttrExpr(attr); err == nil {
+		if _, isTemplate := expr.(*hclsyntax.TemplateExpr); isTemplate && strings.HasPrefix(exprStr, `"`) {
+			return exprStr[1 : len(exprStr)-1] // string template, e.g. "name-${var.suffix}"
+		}
+	}
+	return "${" + exprStr + "}"
+}
+
 // TokensArray creates an array of objects.
 func TokensArray(bodies []*hclwrite.Body) hclwrite.Tokens {
 	tokens := make([]hclwrite.Tokens, 0)
@@ -94,6 +125,18 @@ func TokensArraySingle(body *hclwrite.Body) hclwrite.Tokens {
 	return TokensArray([]*hclwrite.Body{body})
 }
 
+// TokensArrayExprs creates an array of expressions provided as strings, with one element per line if multiline.
+func TokensArrayExprs(exprs []string, multiline bool) hclwrite.Tokens {
+	tokens := make([]hclwrite.Tokens, 0, len(exprs))
+	for _, expr := range exprs {
+		tokens = append(tokens, TokensFromExpr(expr))
+	}
+	if multiline {
+		return EncloseBracketsNewLines(joinTokens(tokens...))
+	}
+	return hclwrite.TokensForTuple(tokens)
+}
+
 // TokensObject creates an object.
 func TokensObject(body *hclwrite.Body) hclwrite.Tokens {
 	tokens := hclwrite.Tokens{tokenNewLine}
@@ -137,11 +180,31 @@ func RemoveLeadingNewline(tokens hclwrite.Tokens) hclwrite.Tokens {
 	return tokens
 }
 
+// RemoveExtraBlankLines keeps at most one blank line between the items of a body, e.g. when several blank lines
+// are left together after removing attributes or blocks.
+func RemoveExtraBlankLines(body *hclwrite.Body) {
+	newlines := 0
+	for _, token := range body.BuildTokens(nil) {
+		if token.Type != hclsyntax.TokenNewline {
+			newlines = 0
+			continue
+		}
+		if newlines++; newlines > 2 {
+			token.Bytes = nil // tokens can't be removed from the body so they're emptied
+		}
+	}
+}
+
 // AppendComment adds a comment at the end of the body.
 func AppendComment(body *hclwrite.Body, comment string) {
 	body.AppendUnstructuredTokens(TokensComment(comment))
 }
 
+// HasComment returns true if body already has the comment, e.g. added by a previous conversion.
+func HasComment(body *hclwrite.Body, comment string) bool {
+	return bytes.Contains(body.BuildTokens(nil).Bytes(), TokensComment(comment).Bytes())
+}
+
 // TokensComment returns the tokens for a comment.
 func TokensComment(comment string) hclwrite.Tokens {
 	return hclwrite.Tokens{
@@ -158,6 +221,43 @@ func GetParser(config []byte) (*hclwrite.File, error) {
 	return parser, nil
 }
 
+// BytesWithTokensAfter returns the content of the file adding the new tokens right after their anchor blocks,
+// e.g. to add a block next to another one. It's needed because hclwrite only allows to append at the end of a body.
+func BytesWithTokensAfter(file *hclwrite.File, newTokens map[*hclwrite.Block]hclwrite.Tokens) []byte {
+	if len(newTokens) == 0 {
+		return file.Bytes()
+	}
+	lastTokens := make(map[*hclwrite.Token]hclwrite.Tokens)
+	for anchor, tokens := range newTokens {
+		if anchorTokens := anchor.BuildTokens(nil); len(anchorTokens) > 0 {
+			lastTokens[anchorTokens[len(anchorTokens)-1]] = tokens
+		}
+	}
+	var tokens hclwrite.Tokens
+	for _, token := range file.Body().BuildTokens(nil) {
+		tokens = append(tokens, token)
+		if extra, found := lastTokens[token]; found {
+			tokens = append(tokens, tokenNewLine)
+			tokens = append(tokens, extra...)
+		}
+	}
+	return hclwrite.Format(tokens.Bytes())
+}
+
+// TokensCommentedOut returns the tokens to add the content as commented out lines.
+func TokensCommentedOut(content []byte) hclwrite.Tokens {
+	var tokens hclwrite.Tokens
+	for line := range strings.Lines(strings.TrimRight(string(content), "\n")) {
+		line = strings.TrimRight(line, " \t\r\n")
+		if line == "" {
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComment, Bytes: []byte("#\n")})
+			continue
+		}
+		tokens = append(tokens, TokensComment(line)...)
+	}
+	return tokens
+}
+
 // joinTokens joins multiple tokens with commas and newlines.
 func joinTokens(tokens ...hclwrite.Tokens) hclwrite.Tokens {
 	ret := hclwrite.Tokens{}
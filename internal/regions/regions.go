@@ -0,0 +1,132 @@
+// Package regions has an embedded catalog of the Atlas region names of each cloud provider, and the equivalent
+// cloud-native names, e.g. US_EAST_1 and us-east-1 in AWS. It's updated manually from the Atlas documentation.
+package regions
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// maxSuggestionDistance is the maximum edit distance of a region name to suggest it as the closest match.
+const maxSuggestionDistance = 2
+
+//go:embed regions.json
+var regionsJSON []byte
+
+// catalog has the Atlas region names of each provider, sorted, and their cloud-native names.
+type catalog struct {
+	native map[string]map[string]string // Atlas name to cloud-native name by provider
+	names  map[string][]string          // sorted Atlas names by provider
+}
+
+var getCatalog = sync.OnceValue(func() *catalog {
+	var native map[string]map[string]string
+	if err := json.Unmarshal(regionsJSON, &native); err != nil {
+		panic(fmt.Sprintf("invalid embedded regions catalog: %v", err))
+	}
+	c := &catalog{native: native, names: make(map[string][]string)}
+	for provider, regions := range native {
+		c.names[provider] = slices.Sorted(maps.Keys(regions))
+	}
+	return c
+})
+
+// Providers returns the sorted cloud providers in the catalog, e.g. AWS, AZURE and GCP.
+// TENANT is not included as the regions of free and flex clusters are the ones of their backing provider.
+func Providers() []string {
+	return slices.Sorted(maps.Keys(getCatalog().names))
+}
+
+// HasProvider returns true if the provider is in the catalog.
+func HasProvider(provider string) bool {
+	return getCatalog().names[provider] != nil
+}
+
+// IsValid returns true if region is an Atlas region name of the provider, e.g. US_EAST_1 in AWS.
+func IsValid(provider, region string) bool {
+	_, found := getCatalog().native[provider][region]
+	return found
+}
+
+// Normalize returns the Atlas region name of a cloud-native name or a name with a different case or separators,
+// e.g. us-east-1 or us_east_1 are US_EAST_1 in AWS, and eastus is US_EAST in AZURE.
+// It returns false if there is no equivalent Atlas name or region is already an Atlas name.
+func Normalize(provider, region string) (string, bool) {
+	if IsValid(provider, region) {
+		return "", false
+	}
+	key := canonical(region)
+	c := getCatalog()
+	for _, name := range c.names[provider] {
+		if key == name || key == canonical(c.native[provider][name]) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Suggest returns the closest Atlas region name of the provider to an invalid region, or an empty string if there
+// is no close match. Cloud-native names are normalized, otherwise names with a small edit distance to the Atlas
+// or cloud-native names are suggested, e.g. US_EAST_1 for US_EAST1 in AWS.
+func Suggest(provider, region string) string {
+	if IsValid(provider, region) {
+		return ""
+	}
+	if name, ok := Normalize(provider, region); ok {
+		return name
+	}
+	key := canonical(region)
+	c := getCatalog()
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, name := range c.names[provider] {
+		distance := min(editDistance(key, name), editDistance(key, canonical(c.native[provider][name])))
+		if distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+// FindProviders returns the sorted providers where region is exactly an Atlas or cloud-native region name,
+// e.g. GCP for CENTRAL_US or us-central1.
+func FindProviders(region string) []string {
+	c := getCatalog()
+	var ret []string
+	for _, provider := range Providers() {
+		if IsValid(provider, region) || slices.Contains(slices.Collect(maps.Values(c.native[provider])), region) {
+			ret = append(ret, provider)
+		}
+	}
+	return ret
+}
+
+// canonical returns the region name in upper case with underscores as separators, e.g. US_EAST_1 for us-east-1.
+func canonical(region string) string {
+	return strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToUpper(strings.TrimSpace(region)))
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,61 @@
+package regions_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/regions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviders(t *testing.T) {
+	assert.Equal(t, []string{"AWS", "AZURE", "GCP"}, regions.Providers())
+	assert.True(t, regions.HasProvider("GCP"))
+	assert.False(t, regions.HasProvider("TENANT"))
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, regions.IsValid("AWS", "US_EAST_1"))
+	assert.True(t, regions.IsValid("GCP", "CENTRAL_US"))
+	assert.True(t, regions.IsValid("AZURE", "EUROPE_WEST"))
+	assert.False(t, regions.IsValid("AWS", "us-east-1"))
+	assert.False(t, regions.IsValid("AWS", "CENTRAL_US"))
+	assert.False(t, regions.IsValid("UNKNOWN", "US_EAST_1"))
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		provider, region, expected string
+		ok                         bool
+	}{
+		{"AWS", "us-east-1", "US_EAST_1", true},
+		{"AWS", "us_west_2", "US_WEST_2", true},
+		{"GCP", "us-central1", "CENTRAL_US", true},
+		{"GCP", "europe-west4", "EUROPE_WEST_4", true},
+		{"AZURE", "westeurope", "EUROPE_WEST", true},
+		{"AWS", "US_EAST_1", "", false},
+		{"AWS", "us-central1", "", false},
+		{"AWS", "us-east1", "", false},
+	}
+	for _, tc := range testCases {
+		name, ok := regions.Normalize(tc.provider, tc.region)
+		assert.Equal(t, tc.ok, ok, "%s %s", tc.provider, tc.region)
+		assert.Equal(t, tc.expected, name, "%s %s", tc.provider, tc.region)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	assert.Equal(t, "US_EAST_1", regions.Suggest("AWS", "us-east-1"))
+	assert.Equal(t, "US_EAST_1", regions.Suggest("AWS", "US_EAST1"))
+	assert.Equal(t, "EU_CENTRAL_1", regions.Suggest("AWS", "EU_CENTRL_1"))
+	assert.Equal(t, "US_EAST", regions.Suggest("AZURE", "east-us"))
+	assert.Empty(t, regions.Suggest("AWS", "US_EAST_1"))
+	assert.Empty(t, regions.Suggest("AWS", "WESTERN_EUROPE"))
+}
+
+func TestFindProviders(t *testing.T) {
+	assert.Equal(t, []string{"GCP"}, regions.FindProviders("us-central1"))
+	assert.Equal(t, []string{"GCP"}, regions.FindProviders("CENTRAL_US"))
+	assert.Equal(t, []string{"AWS", "AZURE", "GCP"}, regions.FindProviders("US_WEST_2"))
+	assert.Empty(t, regions.FindProviders("US_WEST1"))
+	assert.Empty(t, regions.FindProviders("UNKNOWN"))
+}
@@ -0,0 +1,133 @@
+// Package schema has an embedded snapshot of the mongodbatlas provider schema, in the format of
+// terraform providers schema -json. It's updated with make update-schema.
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const providerSuffix = "/mongodb/mongodbatlas"
+
+// Nesting modes of blocks and nested attributes.
+const (
+	NestingSingle = "single"
+	NestingList   = "list"
+	NestingSet    = "set"
+	NestingMap    = "map"
+)
+
+//go:embed provider.json
+var providerJSON []byte
+
+// Block is the schema of a resource, a data source or a nested block.
+type Block struct {
+	Attributes map[string]*Attribute `json:"attributes"`
+	BlockTypes map[string]*BlockType `json:"block_types"`
+}
+
+// BlockType is a nested block of a Block.
+type BlockType struct {
+	Block       *Block `json:"block"`
+	NestingMode string `json:"nesting_mode"`
+	MinItems    int    `json:"min_items"`
+	MaxItems    int    `json:"max_items"`
+}
+
+// Attribute is an attribute of a Block. NestedType is set for nested attributes, Type for the other ones,
+// e.g. "string" or ["map","string"].
+type Attribute struct {
+	NestedType *NestedType     `json:"nested_type"`
+	Type       json.RawMessage `json:"type"`
+	Required   bool            `json:"required"`
+	Optional   bool            `json:"optional"`
+	Computed   bool            `json:"computed"`
+}
+
+// NestedType is the type of a nested attribute.
+type NestedType struct {
+	Attributes  map[string]*Attribute `json:"attributes"`
+	NestingMode string                `json:"nesting_mode"`
+}
+
+type providerSchema struct {
+	ResourceSchemas   map[string]*resourceSchema `json:"resource_schemas"`
+	DataSourceSchemas map[string]*resourceSchema `json:"data_source_schemas"`
+}
+
+type resourceSchema struct {
+	Block   *Block `json:"block"`
+	Version int    `json:"version"`
+}
+
+var getProvider = sync.OnceValue(func() *providerSchema {
+	var file struct {
+		ProviderSchemas map[string]*providerSchema `json:"provider_schemas"`
+	}
+	if err := json.Unmarshal(providerJSON, &file); err != nil {
+		panic(fmt.Sprintf("invalid embedded provider schema: %v", err))
+	}
+	for name, provider := range file.ProviderSchemas {
+		if strings.HasSuffix(name, providerSuffix) {
+			return provider
+		}
+	}
+	panic("mongodbatlas provider not found in embedded provider schema")
+})
+
+// Resource returns the schema of a resource type, or nil if it's not in the snapshot.
+func Resource(name string) *Block {
+	if s := getProvider().ResourceSchemas[name]; s != nil {
+		return s.Block
+	}
+	return nil
+}
+
+// ResourceVersion returns the schema version of a resource type, e.g. the schema_version in the state,
+// and false if it's not in the snapshot.
+func ResourceVersion(name string) (int, bool) {
+	if s := getProvider().ResourceSchemas[name]; s != nil {
+		return s.Version, true
+	}
+	return 0, false
+}
+
+// DataSource returns the schema of a data source type, or nil if it's not in the snapshot.
+func DataSource(name string) *Block {
+	if s := getProvider().DataSourceSchemas[name]; s != nil {
+		return s.Block
+	}
+	return nil
+}
+
+// ResourceTypes returns the sorted resource types in the snapshot.
+func ResourceTypes() []string {
+	return slices.Sorted(maps.Keys(getProvider().ResourceSchemas))
+}
+
+// DataSourceTypes returns the sorted data source types in the snapshot.
+func DataSourceTypes() []string {
+	return slices.Sorted(maps.Keys(getProvider().DataSourceSchemas))
+}
+
+// TypeName returns the name of the attribute type, e.g. string, number, bool, list, set, map or object.
+// It returns an empty string for nested attributes.
+func (a *Attribute) TypeName() string {
+	var name string
+	if json.Unmarshal(a.Type, &name) == nil {
+		return name
+	}
+	var complexType []json.RawMessage
+	if json.Unmarshal(a.Type, &complexType) != nil || len(complexType) == 0 {
+		return ""
+	}
+	if json.Unmarshal(complexType[0], &name) == nil {
+		return name
+	}
+	return ""
+}
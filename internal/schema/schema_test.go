@@ -0,0 +1,42 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource(t *testing.T) {
+	for _, name := range schema.ResourceTypes() {
+		assert.NotNil(t, schema.Resource(name), name)
+	}
+	ear := schema.Resource("mongodbatlas_encryption_at_rest")
+	require.NotNil(t, ear)
+	kms := ear.Attributes["aws_kms_config"]
+	require.NotNil(t, kms)
+	require.NotNil(t, kms.NestedType)
+	assert.Equal(t, schema.NestingSingle, kms.NestedType.NestingMode)
+	user := schema.Resource("mongodbatlas_database_user")
+	require.NotNil(t, user)
+	assert.Equal(t, schema.NestingSet, user.BlockTypes["roles"].NestingMode)
+	assert.Nil(t, schema.Resource("mongodbatlas_unknown"))
+	_, found := schema.ResourceVersion("mongodbatlas_unknown")
+	assert.False(t, found)
+}
+
+func TestDataSource(t *testing.T) {
+	for _, name := range schema.DataSourceTypes() {
+		assert.NotNil(t, schema.DataSource(name), name)
+	}
+	assert.Nil(t, schema.DataSource("mongodbatlas_unknown"))
+}
+
+func TestTypeName(t *testing.T) {
+	flex := schema.Resource("mongodbatlas_flex_cluster")
+	require.NotNil(t, flex)
+	assert.Equal(t, "string", flex.Attributes["name"].TypeName())
+	assert.Equal(t, "map", flex.Attributes["tags"].TypeName())
+	assert.Empty(t, flex.Attributes["provider_settings"].TypeName())
+}
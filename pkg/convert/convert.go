@@ -0,0 +1,88 @@
+package convert
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	internalconvert "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+)
+
+// Converter converts a Terraform configuration file, e.g. ClusterToAdvancedCluster.
+type Converter func(ctx context.Context, config []byte, opts ...Option) (*Result, error)
+
+// Result is the result of a conversion.
+type Result struct {
+	Output   []byte    // converted Terraform configuration file
+	Warnings []Warning // warnings added as comments in Output that require a review
+}
+
+// Warning is a comment added by a conversion that requires a review, e.g. an attribute that was removed.
+type Warning struct {
+	Address string `json:"address"` // block where the warning was added, e.g. mongodbatlas_advanced_cluster.cluster
+	Message string `json:"message"`
+}
+
+// ClusterToAdvancedCluster converts all mongodbatlas_cluster definitions in a Terraform configuration file
+// to mongodbatlas_advanced_cluster Provider 2.0.0, like the clusterToAdvancedCluster (clu2adv) command.
+func ClusterToAdvancedCluster(ctx context.Context, config []byte, opts ...Option) (*Result, error) {
+	return run(ctx, config, internalconvert.ClusterToAdvancedCluster, opts)
+}
+
+// AdvancedClusterToV2 converts all mongodbatlas_advanced_cluster definitions in a Terraform configuration file
+// from Provider 1.X.X to Provider 2.0.0, like the advancedClusterToV2 (adv2v2) command.
+func AdvancedClusterToV2(ctx context.Context, config []byte, opts ...Option) (*Result, error) {
+	return run(ctx, config, internalconvert.AdvancedClusterToV2, opts)
+}
+
+// ServerlessToFlex converts all mongodbatlas_serverless_instance definitions in a Terraform configuration file
+// to mongodbatlas_flex_cluster, like the serverlessToFlex (serverless2flex) command.
+func ServerlessToFlex(ctx context.Context, config []byte, opts ...Option) (*Result, error) {
+	return run(ctx, config, internalconvert.ServerlessToFlex, opts)
+}
+
+// ResourcesToV2 converts the blocks that are nested attributes in Provider 2.0.0 in the other mongodbatlas
+// resources and data sources of a Terraform configuration file, like the resourcesToV2 (res2v2) command.
+func ResourcesToV2(ctx context.Context, config []byte, opts ...Option) (*Result, error) {
+	return run(ctx, config, internalconvert.ResourcesToV2, opts)
+}
+
+// Convert reads a Terraform configuration file from r, converts it with convert and writes the result to w.
+// Nothing is written if the conversion fails.
+func Convert(ctx context.Context, convert Converter, r io.Reader, w io.Writer, opts ...Option) (*Result, error) {
+	config, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	result, err := convert(ctx, config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(result.Output); err != nil {
+		return nil, fmt.Errorf("failed to write converted config: %w", err)
+	}
+	return result, nil
+}
+
+func run(ctx context.Context, config []byte, convertFn func([]byte, internalconvert.Options) ([]byte, error),
+	opts []Option) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	output, err := convertFn(config, getOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	warnings, err := internalconvert.CollectWarnings(config, output)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Output: output, Warnings: make([]Warning, 0, len(warnings))}
+	for _, warning := range warnings {
+		result.Warnings = append(result.Warnings, Warning(warning))
+	}
+	return result, nil
+}
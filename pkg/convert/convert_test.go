@@ -0,0 +1,147 @@
+package convert_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/pkg/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clusterConfig = `
+resource "mongodbatlas_cluster" "cluster" {
+  project_id                  = var.project_id
+  name                        = "cluster"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M10"
+  provider_encrypt_ebs_volume = true # original comment
+  replication_specs {
+    num_shards = 1
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+    }
+  }
+}
+`
+
+func TestClusterToAdvancedCluster(t *testing.T) {
+	result, err := convert.ClusterToAdvancedCluster(t.Context(), []byte(clusterConfig), convert.WithIncludeMoved())
+	require.NoError(t, err)
+	output := string(result.Output)
+	assert.Contains(t, output, `resource "mongodbatlas_advanced_cluster" "cluster"`)
+	assert.Contains(t, output, "moved {")
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "mongodbatlas_advanced_cluster.cluster", result.Warnings[0].Address)
+	assert.Contains(t, result.Warnings[0].Message, "provider_encrypt_ebs_volume was removed")
+}
+
+func TestWithOptions(t *testing.T) {
+	result, err := convert.ClusterToAdvancedCluster(t.Context(), []byte(clusterConfig),
+		convert.WithOptions(convert.Options{IncludeMoved: true, KeepOriginal: true}), convert.WithIncludeCheck())
+	require.NoError(t, err)
+	output := string(result.Output)
+	assert.Contains(t, output, "moved {")
+	assert.Contains(t, output, "check ")
+	assert.Contains(t, output, "# resource \"mongodbatlas_cluster\" \"cluster\" {")
+	assert.Len(t, result.Warnings, 1, "commented out original resource must not be a warning")
+}
+
+func TestWithNormalizeRegions(t *testing.T) {
+	config := strings.Replace(clusterConfig, "US_EAST_1", "us-east-1", 1)
+	result, err := convert.ClusterToAdvancedCluster(t.Context(), []byte(config))
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Output), `region_name   = "us-east-1"`)
+	require.Len(t, result.Warnings, 2)
+	assert.Contains(t, result.Warnings[1].Message, "did you mean US_EAST_1?")
+
+	result, err = convert.ClusterToAdvancedCluster(t.Context(), []byte(config), convert.WithNormalizeRegions())
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Output), `region_name   = "US_EAST_1"`)
+	require.Len(t, result.Warnings, 2)
+	assert.Contains(t, result.Warnings[1].Message, "us-east-1 was changed to the Atlas region name US_EAST_1")
+}
+
+func TestAdvancedClusterToV2(t *testing.T) {
+	config := `
+resource "mongodbatlas_advanced_cluster" "cluster" {
+  project_id   = var.project_id
+  name         = "cluster"
+  cluster_type = "REPLICASET"
+  replication_specs {
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 3
+      }
+    }
+  }
+}
+`
+	result, err := convert.AdvancedClusterToV2(t.Context(), []byte(config))
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Output), "replication_specs = [")
+	assert.Empty(t, result.Warnings)
+}
+
+func TestServerlessToFlex(t *testing.T) {
+	config := `
+resource "mongodbatlas_serverless_instance" "instance" {
+  project_id                              = var.project_id
+  name                                    = "instance"
+  provider_settings_backing_provider_name = "AWS"
+  provider_settings_provider_name         = "SERVERLESS"
+  provider_settings_region_name           = "US_EAST_1"
+  continuous_backup_enabled               = true
+}
+`
+	result, err := convert.ServerlessToFlex(t.Context(), []byte(config))
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Output), `resource "mongodbatlas_flex_cluster" "instance"`)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "mongodbatlas_flex_cluster.instance", result.Warnings[0].Address)
+}
+
+func TestResourcesToV2(t *testing.T) {
+	config := `
+resource "mongodbatlas_encryption_at_rest" "ear" {
+  project_id = var.project_id
+  aws_kms_config {
+    enabled = true
+  }
+}
+`
+	result, err := convert.ResourcesToV2(t.Context(), []byte(config))
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Output), "aws_kms_config = {")
+	assert.Empty(t, result.Warnings)
+}
+
+func TestConvert(t *testing.T) {
+	var out bytes.Buffer
+	result, err := convert.Convert(t.Context(), convert.ClusterToAdvancedCluster, strings.NewReader(clusterConfig), &out)
+	require.NoError(t, err)
+	assert.Equal(t, string(result.Output), out.String())
+}
+
+func TestConvertError(t *testing.T) {
+	var out bytes.Buffer
+	_, err := convert.Convert(t.Context(), convert.ClusterToAdvancedCluster, strings.NewReader("resource {"), &out)
+	require.ErrorContains(t, err, "failed to parse Terraform config file")
+	assert.Empty(t, out.String())
+}
+
+func TestCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := convert.ClusterToAdvancedCluster(ctx, []byte(clusterConfig))
+	require.ErrorIs(t, err, context.Canceled)
+}
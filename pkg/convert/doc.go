@@ -0,0 +1,11 @@
+// Package convert is the public Go API of the conversions done by the atlas-cli-plugin-terraform commands,
+// so they can be used without running the Atlas CLI, e.g.:
+//
+//	result, err := convert.ClusterToAdvancedCluster(ctx, config, convert.WithIncludeMoved())
+//
+// This package is part of the atlas-cli-plugin-terraform module, so it's released with the same version tags as
+// the CLI. Exported identifiers are not removed or changed in an incompatible way in minor or patch releases.
+// New options are added as new Option functions and new fields in Options, whose zero value keeps the previous
+// behavior. The converted configuration and the warning messages are not part of the API, they can change in any
+// release like the output of the CLI commands.
+package convert
@@ -0,0 +1,21 @@
+package convert_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/pkg/convert"
+)
+
+func ExampleClusterToAdvancedCluster() {
+	result, err := convert.ClusterToAdvancedCluster(context.Background(), []byte(clusterConfig),
+		convert.WithIncludeMoved())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, warning := range result.Warnings {
+		fmt.Println(warning.Address)
+	}
+	// Output: mongodbatlas_advanced_cluster.cluster
+}
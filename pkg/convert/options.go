@@ -0,0 +1,86 @@
+package convert
+
+import (
+	internalconvert "github.com/mongodb-labs/atlas-cli-plugin-terraform/internal/convert"
+)
+
+// Options are the conversion options, the zero value uses the default behavior of the CLI commands.
+// Not all options are used by every conversion, e.g. ConvertFlex is only used by ClusterToAdvancedCluster.
+type Options struct {
+	IncludeMoved     bool // include moved blocks for the converted resources
+	CompactShards    bool // use a for expression instead of repeating the replication_specs for literal num_shards
+	ExtractLocals    bool // move generated expressions like replication_specs from dynamic blocks to locals
+	KeepOriginal     bool // keep a commented out copy of the original resources
+	IncludeRemoved   bool // include removed and import blocks for the converted resources
+	IncludeImport    bool // include import blocks for the converted resources
+	IncludeCheck     bool // include check blocks asserting the topology of the converted resources
+	ConvertFlex      bool // convert shared-tier clusters (M2 and M5) to flex clusters instead of advanced clusters
+	UpdateProvider   bool // update the mongodbatlas version constraint in required_providers to Provider 2.X.X
+	NormalizeRegions bool // change cloud-native region names like us-east-1 to Atlas region names like US_EAST_1
+}
+
+// Option sets a conversion option.
+type Option func(*Options)
+
+// WithOptions sets all the options at once, options passed after it can still change them.
+func WithOptions(opts Options) Option {
+	return func(o *Options) { *o = opts }
+}
+
+// WithIncludeMoved includes moved blocks for the converted resources.
+func WithIncludeMoved() Option {
+	return func(o *Options) { o.IncludeMoved = true }
+}
+
+// WithCompactShards uses a for expression instead of repeating replication_specs for each shard.
+func WithCompactShards() Option {
+	return func(o *Options) { o.CompactShards = true }
+}
+
+// WithExtractLocals moves replication_specs expressions generated from dynamic blocks to locals.
+func WithExtractLocals() Option {
+	return func(o *Options) { o.ExtractLocals = true }
+}
+
+// WithKeepOriginal keeps a commented out copy of the original resources after the converted ones.
+func WithKeepOriginal() Option {
+	return func(o *Options) { o.KeepOriginal = true }
+}
+
+// WithIncludeRemoved includes removed and import blocks instead of moved blocks.
+func WithIncludeRemoved() Option {
+	return func(o *Options) { o.IncludeRemoved = true }
+}
+
+// WithIncludeImport includes import blocks instead of moved blocks.
+func WithIncludeImport() Option {
+	return func(o *Options) { o.IncludeImport = true }
+}
+
+// WithIncludeCheck includes check blocks asserting the topology of the converted clusters.
+func WithIncludeCheck() Option {
+	return func(o *Options) { o.IncludeCheck = true }
+}
+
+// WithConvertFlex converts shared-tier clusters (M2 and M5) to mongodbatlas_flex_cluster.
+func WithConvertFlex() Option {
+	return func(o *Options) { o.ConvertFlex = true }
+}
+
+// WithUpdateProvider updates the mongodbatlas version constraint in required_providers to Provider 2.X.X.
+func WithUpdateProvider() Option {
+	return func(o *Options) { o.UpdateProvider = true }
+}
+
+// WithNormalizeRegions changes cloud-native region names like us-east-1 to Atlas region names like US_EAST_1.
+func WithNormalizeRegions() Option {
+	return func(o *Options) { o.NormalizeRegions = true }
+}
+
+func getOptions(opts []Option) internalconvert.Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return internalconvert.Options(o)
+}
@@ -0,0 +1,32 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Updates the snapshot of the mongodbatlas provider schema embedded in internal/schema,
+# all the resource and data source types are kept so any of them can be converted and validated.
+
+PROVIDER_VERSION="${PROVIDER_VERSION:-~> 2.0}"
+OUT_FILE="$(pwd)/internal/schema/provider.json"
+
+TMP_DIR="$(mktemp -d)"
+trap 'rm -rf "$TMP_DIR"' EXIT
+
+cat > "${TMP_DIR}/main.tf" <<TF
+terraform {
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "${PROVIDER_VERSION}"
+    }
+  }
+}
+TF
+
+echo "==> Getting mongodbatlas provider schema"
+terraform -chdir="$TMP_DIR" init -input=false > /dev/null
+terraform -chdir="$TMP_DIR" providers schema -json |
+  jq --sort-keys --indent 2 '
+    .provider_schemas |= with_entries(select(.key | endswith("/mongodb/mongodbatlas")) | .value |= {
+      resource_schemas: .resource_schemas,
+      data_source_schemas: .data_source_schemas
+    })' > "$OUT_FILE"
+echo "==> Schema written to ${OUT_FILE}"
@@ -1,7 +1,9 @@
 package e2e
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"maps"
 	"os"
 	"os/exec"
@@ -14,6 +16,30 @@ import (
 )
 
 func RunTF(args ...string) (string, error) {
+	resp, err := tfCommand(args...).CombinedOutput()
+	return string(resp), err
+}
+
+func RunTFCommand(command string, args ...string) (string, error) {
+	args = append([]string{command}, args...)
+	return RunTF(args...)
+}
+
+// RunTFCommandOutput runs a command like RunTFCommand but returns stdout and stderr separately, and the exit code.
+func RunTFCommandOutput(command string, args ...string) (stdout, stderr string, exitCode int, err error) {
+	var outBuf, errBuf bytes.Buffer
+	cmd := tfCommand(append([]string{command}, args...)...)
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return outBuf.String(), errBuf.String(), exitErr.ExitCode(), nil
+	}
+	return outBuf.String(), errBuf.String(), 0, err
+}
+
+func tfCommand(args ...string) *exec.Cmd {
 	ctx := context.Background()
 
 	// Ensure Atlas CLI storage warning is silenced before running tests as it is not enabled in GitHub Actions
@@ -23,14 +49,7 @@ func RunTF(args ...string) (string, error) {
 	}
 
 	args = append([]string{"tf"}, args...)
-	cmd := exec.CommandContext(ctx, "atlas", args...)
-	resp, err := cmd.CombinedOutput()
-	return string(resp), err
-}
-
-func RunTFCommand(command string, args ...string) (string, error) {
-	args = append([]string{command}, args...)
-	return RunTF(args...)
+	return exec.CommandContext(ctx, "atlas", args...)
 }
 
 func CompareFiles(t *testing.T, fs afero.Fs, file1, file2 string) {
@@ -132,3 +151,32 @@ func RunTests(t *testing.T, cmdName string, extraTests map[string]TestCase) {
 		})
 	}
 }
+
+// ReportTestCase is a test case of a command that writes a report, e.g. lint. Issues are written to stdout, and
+// warnings and errors to stderr.
+type ReportTestCase struct {
+	Args           []string
+	StdoutContains []string
+	StderrContains []string
+	ExitCode       int
+}
+
+// RunReportTests runs the test cases of a command that writes a report, checking the exit code, that the report
+// is only written to stdout, and that the warnings and errors are written to stderr.
+func RunReportTests(t *testing.T, cmdName string, tests map[string]ReportTestCase) {
+	t.Helper()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stdout, stderr, exitCode, err := RunTFCommandOutput(cmdName, tc.Args...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExitCode, exitCode, "stdout: %s\nstderr: %s", stdout, stderr)
+			for _, text := range tc.StdoutContains {
+				assert.Contains(t, stdout, text)
+				assert.NotContains(t, stderr, text)
+			}
+			for _, text := range tc.StderrContains {
+				assert.Contains(t, stderr, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/test/e2e"
+)
+
+func TestLint(t *testing.T) {
+	files := e2e.GetTestFiles(t, "lint")
+	e2e.RunReportTests(t, "lint", map[string]e2e.ReportTestCase{
+		"no params": {
+			ExitCode:       1,
+			StderrContains: []string{"required flag(s) \"file\" not set"},
+		},
+		"issues": {
+			Args:     []string{"--file", files.FileIn},
+			ExitCode: 1,
+			StdoutContains: []string{
+				files.FileIn + ":6: mongodbatlas_advanced_cluster.cluster: replication_specs[0]: " +
+					"electable nodes must be 3, 5 or 7 in the shard, found 2",
+			},
+			StderrContains: []string{
+				"WARNING: topology not linted, mongodbatlas_advanced_cluster.variable",
+				"Error: found 1 topology issues in clusters",
+			},
+		},
+		"valid": {
+			Args:           []string{"--file", files.GetCustomFilePath("valid.tf")},
+			StdoutContains: []string{"No topology issues found in clusters"},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/test/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateState(t *testing.T) {
+	files := e2e.GetTestFiles(t, "migrateState")
+	fileIn := files.GetCustomFilePath("in.tfstate")
+	fileOut := files.GetCustomFilePath("out.tfstate")
+	fileExpected := files.GetCustomFilePath("expected.tfstate")
+	e2e.RunReportTests(t, "migrateState", map[string]e2e.ReportTestCase{
+		"no params": {
+			ExitCode:       1,
+			StderrContains: []string{"required flag(s) \"file\", \"output\" not set"},
+		},
+		"unexisting input file": {
+			Args:           []string{"--file", files.FileUnexisting, "--output", fileOut},
+			ExitCode:       1,
+			StderrContains: []string{"file must exist: " + files.FileUnexisting},
+		},
+		"existing output file without replaceOutput flag": {
+			Args:           []string{"--file", fileIn, "--output", fileExpected},
+			ExitCode:       1,
+			StderrContains: []string{"file must not exist: " + fileExpected},
+		},
+	})
+	t.Run("basic use", func(t *testing.T) {
+		stdout, stderr, exitCode, err := e2e.RunTFCommandOutput("migrateState", "--file", fileIn, "--output", fileOut)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = files.Fs.Remove(fileOut) })
+		assert.Zero(t, exitCode, stderr)
+		assert.Empty(t, stdout)
+		assert.Empty(t, stderr)
+		e2e.CompareFiles(t, files.Fs, fileOut, fileExpected)
+	})
+}
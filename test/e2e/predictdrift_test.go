@@ -0,0 +1,36 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/test/e2e"
+)
+
+func TestPredictDrift(t *testing.T) {
+	files := e2e.GetTestFiles(t, "predictDrift")
+	state := files.GetCustomFilePath("in.tfstate")
+	e2e.RunReportTests(t, "predictDrift", map[string]e2e.ReportTestCase{
+		"no params": {
+			ExitCode:       1,
+			StderrContains: []string{"required flag(s) \"file\", \"state\" not set"},
+		},
+		"unexisting state file": {
+			Args:           []string{"--file", files.FileIn, "--state", files.FileUnexisting},
+			ExitCode:       1,
+			StderrContains: []string{"file must exist: " + files.FileUnexisting},
+		},
+		"differences": {
+			Args:     []string{"--file", files.FileIn, "--state", state},
+			ExitCode: 1,
+			StdoutContains: []string{
+				"mongodbatlas_advanced_cluster.from_cluster: tags.team: state \"db\", config not set\n",
+				"mongodbatlas_advanced_cluster.count[1]: replication_specs: state has 2 elements, config has 1\n",
+			},
+			StderrContains: []string{"Error: found 4 fields with differences between configuration and state"},
+		},
+		"no differences": {
+			Args:           []string{"--file", files.FileIn, "--state", state, "--modulePath", "module.other"},
+			StdoutContains: []string{"No differences found between configuration and state"},
+		},
+	})
+}
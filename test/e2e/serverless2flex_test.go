@@ -0,0 +1,11 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/test/e2e"
+)
+
+func TestServerlessToFlex(t *testing.T) {
+	e2e.RunTests(t, "serverless2flex", nil)
+}
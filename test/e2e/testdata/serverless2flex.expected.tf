@@ -0,0 +1,60 @@
+resource "mongodbatlas_flex_cluster" "basic" {
+  project_id                     = var.project_id
+  name                           = "serverless-basic"
+  termination_protection_enabled = true
+
+  provider_settings = {
+    backing_provider_name = "AWS"
+    region_name           = "US_EAST_1"
+  }
+  tags = {
+    environment = "dev"
+    team        = var.team
+  }
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_flex_cluster" "backup" {
+  for_each   = var.instances
+  project_id = var.project_id
+  name       = each.key
+
+  provider_settings = {
+    backing_provider_name = each.value.provider
+    region_name           = each.value.region
+  }
+  tags = var.tags
+
+  lifecycle {
+    prevent_destroy = true
+  }
+
+  # Attributes not supported in mongodbatlas_flex_cluster were removed: auto_indexing, timeouts.
+  # continuous_backup_enabled was removed, flex clusters have daily snapshots instead of continuous cloud backup.
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+resource "mongodbatlas_advanced_cluster" "untouched" {
+  project_id   = var.project_id
+  name         = "untouched"
+  cluster_type = "REPLICASET"
+}
+
+data "mongodbatlas_flex_cluster" "basic" {
+  project_id = mongodbatlas_serverless_instance.basic.project_id
+  name       = mongodbatlas_serverless_instance.basic.name
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
+
+data "mongodbatlas_flex_clusters" "all" {
+  project_id = var.project_id
+
+  # Generated by atlas-cli-plugin-terraform.
+  # Please review the changes and confirm that references to this resource are updated.
+}
@@ -0,0 +1,35 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/mongodb-labs/atlas-cli-plugin-terraform/test/e2e"
+)
+
+func TestVerifyPlan(t *testing.T) {
+	files := e2e.GetTestFiles(t, "verifyPlan")
+	e2e.RunReportTests(t, "verifyPlan", map[string]e2e.ReportTestCase{
+		"no params": {
+			ExitCode:       1,
+			StderrContains: []string{"required flag(s) \"file\" not set"},
+		},
+		"unexisting input file": {
+			Args:           []string{"--file", files.FileUnexisting},
+			ExitCode:       1,
+			StderrContains: []string{"file must exist: " + files.FileUnexisting},
+		},
+		"unsafe": {
+			Args:     []string{"--file", files.GetCustomFilePath("unsafe.json")},
+			ExitCode: 1,
+			StdoutContains: []string{
+				"mongodbatlas_advanced_cluster.resized: update\n  - moved from mongodbatlas_cluster.resized\n",
+				"mongodbatlas_cluster.deleted: delete\n",
+			},
+			StderrContains: []string{"Error: plan has 5 unsafe changes in clusters"},
+		},
+		"safe": {
+			Args:           []string{"--file", files.GetCustomFilePath("safe.json")},
+			StdoutContains: []string{"No unsafe changes in clusters found in the plan"},
+		},
+	})
+}